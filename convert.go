@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/urfave/cli"
+)
+
+// sourceFormats are the outformats which are schema sources themselves, so
+// they keep the columns untyped where the input leaves them untyped instead
+// of writing the types ResolveTypes infers.
+var sourceFormats = map[string]bool{"erd": true, "dbml": true, "prisma": true}
+
+// convertCommand is the convert command, which writes the converted schema
+// to wr.
+func convertCommand(wr io.Writer) cli.Command {
+	return cli.Command{
+		Name:      "convert",
+		Aliases:   []string{"c"},
+		ArgsUsage: "[file]",
+		Usage:     "convert erd file to dot/json/erd/mermaid/plantuml/sql/markdown/svg/ascii/dbml/prisma/graphql/dbt/bigquery-json/avro/openapi",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "informat",
+				Value: "erd",
+				Usage: "input format. erd, dbml and prisma is available.",
+			},
+			cli.StringFlag{
+				Name:  "outformat",
+				Value: "dot",
+				Usage: "output format. dot, json, erd, mermaid, plantuml, sql, markdown, svg, ascii, dbml, prisma, graphql, dbt, bigquery-json, avro and openapi is available.",
+			},
+			cli.StringFlag{
+				Name:  "dialect",
+				Value: "postgres",
+				Usage: "SQL dialect of the sql outformat. postgres, mysql and sqlite is available.",
+			},
+			cli.IntFlag{
+				Name:  "json-version",
+				Value: JSONVersion,
+				Usage: "version of the json outformat. 0 is the unversioned output of older releases.",
+			},
+			cli.StringFlag{
+				Name:  "openapi-format",
+				Value: "yaml",
+				Usage: "format of the openapi outformat. yaml and json is available.",
+			},
+			cli.StringFlag{
+				Name:  "types",
+				Usage: "JSON file mapping column types for the bigquery-json, avro and openapi outformats, like {\"types\": {\"uuid\": \"STRING\"}}.",
+			},
+			cli.StringFlag{
+				Name:  "template",
+				Usage: "text/template file to render with the parsed tables instead of outformat.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			_, text, err := ReadSource(c)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			parser, err := ParseInput(c.String("informat"), text)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			if !sourceFormats[c.String("outformat")] {
+				for _, mismatch := range ResolveTypes(parser.Tables()) {
+					fmt.Fprintf(os.Stderr, "warning: %v\n", mismatch)
+				}
+			}
+
+			if path := c.String("template"); path != "" {
+				text, err := ioutil.ReadFile(path)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				if err := ExportTemplate(parser, string(text), wr); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				return nil
+			}
+
+			switch c.String("outformat") {
+			case "json":
+				err = ExportJSON(parser, c.Int("json-version"), wr)
+			case "erd":
+				err = ExportERD(parser, wr)
+			case "mermaid":
+				err = ExportMermaid(parser, wr)
+			case "plantuml":
+				err = ExportPlantUML(parser, wr)
+			case "sql":
+				err = ExportSQL(parser, c.String("dialect"), wr)
+			case "markdown":
+				err = ExportMarkdown(parser, wr)
+			case "svg":
+				err = ExportSVG(parser, wr)
+			case "ascii":
+				err = ExportASCII(parser, wr)
+			case "dbml":
+				err = ExportDBML(parser, wr)
+			case "prisma":
+				err = ExportPrisma(parser, wr)
+			case "graphql":
+				err = ExportGraphQL(parser, wr)
+			case "dbt":
+				err = ExportDBT(parser, wr)
+			case "bigquery-json":
+				var types TypeMap
+				if types, err = convertTypes(c, BigQueryTypes); err == nil {
+					err = ExportBigQuery(parser, types, wr)
+				}
+			case "avro":
+				var types TypeMap
+				if types, err = convertTypes(c, AvroTypes); err == nil {
+					err = ExportAvro(parser, types, wr)
+				}
+			case "openapi":
+				var types TypeMap
+				if types, err = convertTypes(c, OpenAPITypes); err == nil {
+					err = ExportOpenAPI(parser, types, c.String("openapi-format"), wr)
+				}
+			case "dot":
+				err = ExportDot(parser, wr)
+			default:
+				err = fmt.Errorf("unknown output format %q (available: dot, json, erd, mermaid, plantuml, sql, markdown, svg, ascii, dbml, prisma, graphql, dbt, bigquery-json, avro, openapi)", c.String("outformat"))
+			}
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			return nil
+		},
+	}
+}
+
+// convertTypes returns the type map of an outformat, with the types of the
+// --types file if one is given.
+func convertTypes(c *cli.Context, base TypeMap) (TypeMap, error) {
+	if path := c.String("types"); path != "" {
+		return LoadTypeMap(path, base)
+	}
+	return base, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/urfave/cli"
+)
+
+func TestConvertCommand(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		app := cli.NewApp()
+		app.Commands = []cli.Command{convertCommand(&buf)}
+		err := app.Run(append([]string{"erd", "convert"}, args...))
+		return buf.String(), err
+	}
+
+	Convey("The schema is read from the file given as argument", t, func() {
+		out, err := run("--outformat", "ascii", "sample.erd")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, golden(t, "sample.txt", []byte(out)))
+	})
+
+	Convey("Relation columns stay untyped in the erd outformat", t, func() {
+		out, err := run("--outformat", "erd", "testdata/untyped.erd")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, golden(t, "untyped.erd", []byte(out)))
+	})
+
+	Convey("Missing files are errors", t, func() {
+		exiter, errWriter := cli.OsExiter, cli.ErrWriter
+		defer func() { cli.OsExiter, cli.ErrWriter = exiter, errWriter }()
+		code := 0
+		cli.OsExiter = func(c int) { code = c }
+		cli.ErrWriter = &bytes.Buffer{}
+
+		out, err := run("--outformat", "ascii", "testdata/missing.erd")
+		So(err, ShouldNotBeNil)
+		So(code, ShouldEqual, 1)
+		So(out, ShouldBeEmpty)
+	})
+
+	Convey("Unknown outformats are errors listing the available ones", t, func() {
+		exiter, errWriter := cli.OsExiter, cli.ErrWriter
+		defer func() { cli.OsExiter, cli.ErrWriter = exiter, errWriter }()
+		code := 0
+		cli.OsExiter = func(c int) { code = c }
+		var stderr bytes.Buffer
+		cli.ErrWriter = &stderr
+
+		out, err := run("--outformat", "png", "sample.erd")
+		So(err, ShouldNotBeNil)
+		So(code, ShouldEqual, 1)
+		So(out, ShouldBeEmpty)
+		So(stderr.String(), ShouldContainSubstring, `unknown output format "png" (available: dot, json, erd,`)
+	})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+//go:embed templates/doc
+var docTemplates embed.FS
+
+var docPageInvalid = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// docPage returns the file name of the page of the table named name.
+func docPage(name string) string {
+	return docPageInvalid.ReplaceAllString(name, "_") + ".html"
+}
+
+// docSearchText returns the lower-cased words the index search matches a
+// table by.
+func docSearchText(t Table) string {
+	words := []string{t.Name, t.Description}
+	for _, c := range t.Columns {
+		words = append(words, c.Name, c.Description)
+	}
+	return strings.ToLower(strings.Join(words, " "))
+}
+
+func executeDocTemplate(name string, funcs template.FuncMap, data interface{}, path string) error {
+	tmpl, err := template.New(name).Funcs(funcs).ParseFS(docTemplates, "templates/doc/"+name)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// GenerateDoc writes a static HTML site documenting the tables to dir: an
+// index page with a search box and a diagram, and a page per table in
+// dir/tables. The pages use no external resources.
+func GenerateDoc(p ParsedData, dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, "tables"), 0755); err != nil {
+		return err
+	}
+
+	css, err := docTemplates.ReadFile("templates/doc/style.css")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "style.css"), css, 0644); err != nil {
+		return err
+	}
+
+	indexPage := func(name string) string {
+		return "tables/" + docPage(name)
+	}
+	index := struct {
+		Tables  []Table
+		Diagram template.HTML
+	}{p.Tables(), template.HTML(RenderSVG(p.Tables(), SVGOptions{Link: indexPage}))}
+	funcs := template.FuncMap{"tablePage": indexPage, "searchText": docSearchText}
+	if err := executeDocTemplate("index.html.tmpl", funcs, index, filepath.Join(dir, "index.html")); err != nil {
+		return err
+	}
+
+	funcs = template.FuncMap{"tablePage": docPage}
+	for _, t := range p.Tables() {
+		page := struct {
+			Table        Table
+			ReferencedBy []Reference
+		}{t, ReferencesTo(p.Tables(), t.Name)}
+		if err := executeDocTemplate("table.html.tmpl", funcs, page, filepath.Join(dir, "tables", docPage(t.Name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateDoc(t *testing.T) {
+	Convey("A page is written for the index and each table", t, func() {
+		dir, err := ioutil.TempDir("", "erd-doc")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(GenerateDoc(parseSample(t), dir), ShouldBeNil)
+
+		index, err := ioutil.ReadFile(filepath.Join(dir, "index.html"))
+		So(err, ShouldBeNil)
+		So(string(index), ShouldContainSubstring, `<a href="tables/User.html">User</a> <span class="description">All our customers</span>`)
+		So(string(index), ShouldContainSubstring, `data-search="user all our customers id  email user&#39;s email address name user&#39;s name"`)
+		So(string(index), ShouldContainSubstring, "<a href=\"tables/Category.html\">\n<rect")
+		So(string(index), ShouldNotContainSubstring, "http://cdn")
+
+		page, err := ioutil.ReadFile(filepath.Join(dir, "tables", "Blog.html"))
+		So(err, ShouldBeNil)
+		So(string(page), ShouldContainSubstring, `<a href="User.html#id">User.id</a>`)
+		So(string(page), ShouldContainSubstring, `<li><a href="Post.html#blog_id">Post.blog_id</a> &rarr; <code>id</code></li>`)
+
+		_, err = os.Stat(filepath.Join(dir, "style.css"))
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Page names are safe file names", t, func() {
+		So(docPage("User"), ShouldEqual, "User.html")
+		So(docPage("../a b"), ShouldEqual, "___a_b.html")
+	})
+}
@@ -1,11 +1,14 @@
 package main
 
+// Code generated by peg erd.peg DO NOT EDIT.
+
 import (
-	"strings"
 	"fmt"
-	"math"
+	"io"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 const endSymbol rune = 1114112
@@ -26,6 +29,7 @@ const (
 	ruleColumns
 	ruleColumn
 	ruleColumnDescription
+	ruleQuoted
 	ruledot
 	ruleColumnName
 	ruleColumnDef
@@ -36,6 +40,7 @@ const (
 	ruleTargetTableName
 	ruleTargetColumnName
 	ruleEOT
+	ruleComment
 	ruleAction0
 	rulePegText
 	ruleAction1
@@ -48,6 +53,7 @@ const (
 	ruleAction8
 	ruleAction9
 	ruleAction10
+	ruleAction11
 )
 
 var rul3s = [...]string{
@@ -63,6 +69,7 @@ var rul3s = [...]string{
 	"Columns",
 	"Column",
 	"ColumnDescription",
+	"Quoted",
 	"dot",
 	"ColumnName",
 	"ColumnDef",
@@ -73,6 +80,7 @@ var rul3s = [...]string{
 	"TargetTableName",
 	"TargetColumnName",
 	"EOT",
+	"Comment",
 	"Action0",
 	"PegText",
 	"Action1",
@@ -85,6 +93,7 @@ var rul3s = [...]string{
 	"Action8",
 	"Action9",
 	"Action10",
+	"Action11",
 }
 
 type token32 struct {
@@ -101,19 +110,19 @@ type node32 struct {
 	up, next *node32
 }
 
-func (node *node32) print(pretty bool, buffer string) {
+func (node *node32) print(w io.Writer, pretty bool, buffer string) {
 	var print func(node *node32, depth int)
 	print = func(node *node32, depth int) {
 		for node != nil {
 			for c := 0; c < depth; c++ {
-				fmt.Printf(" ")
+				fmt.Fprintf(w, " ")
 			}
 			rule := rul3s[node.pegRule]
 			quote := strconv.Quote(string(([]rune(buffer)[node.begin:node.end])))
 			if !pretty {
-				fmt.Printf("%v %v\n", rule, quote)
+				fmt.Fprintf(w, "%v %v\n", rule, quote)
 			} else {
-				fmt.Printf("\x1B[34m%v\x1B[m %v\n", rule, quote)
+				fmt.Fprintf(w, "\x1B[36m%v\x1B[m %v\n", rule, quote)
 			}
 			if node.up != nil {
 				print(node.up, depth+1)
@@ -124,12 +133,12 @@ func (node *node32) print(pretty bool, buffer string) {
 	print(node, 0)
 }
 
-func (node *node32) Print(buffer string) {
-	node.print(false, buffer)
+func (node *node32) Print(w io.Writer, buffer string) {
+	node.print(w, false, buffer)
 }
 
-func (node *node32) PrettyPrint(buffer string) {
-	node.print(true, buffer)
+func (node *node32) PrettyPrint(w io.Writer, buffer string) {
+	node.print(w, true, buffer)
 }
 
 type tokens32 struct {
@@ -172,24 +181,24 @@ func (t *tokens32) AST() *node32 {
 }
 
 func (t *tokens32) PrintSyntaxTree(buffer string) {
-	t.AST().Print(buffer)
+	t.AST().Print(os.Stdout, buffer)
+}
+
+func (t *tokens32) WriteSyntaxTree(w io.Writer, buffer string) {
+	t.AST().Print(w, buffer)
 }
 
 func (t *tokens32) PrettyPrintSyntaxTree(buffer string) {
-	t.AST().PrettyPrint(buffer)
+	t.AST().PrettyPrint(os.Stdout, buffer)
 }
 
 func (t *tokens32) Add(rule pegRule, begin, end, index uint32) {
-	if tree := t.tree; int(index) >= len(tree) {
-		expanded := make([]token32, 2*len(tree))
-		copy(expanded, tree)
-		t.tree = expanded
-	}
-	t.tree[index] = token32{
-		pegRule: rule,
-		begin:   begin,
-		end:     end,
+	tree, i := t.tree, int(index)
+	if i >= len(tree) {
+		t.tree = append(tree, token32{pegRule: rule, begin: begin, end: end})
+		return
 	}
+	tree[i] = token32{pegRule: rule, begin: begin, end: end}
 }
 
 func (t *tokens32) Tokens() []token32 {
@@ -197,13 +206,15 @@ func (t *tokens32) Tokens() []token32 {
 }
 
 type Parser struct {
-	tables []Table
-	table  *Table
-	column *Column
+	tables   []Table
+	table    *Table
+	column   *Column
+	comments []Comment
+	newlines []int
 
 	Buffer string
 	buffer []rune
-	rules  [34]func() bool
+	rules  [37]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -255,7 +266,7 @@ type parseError struct {
 }
 
 func (e *parseError) Error() string {
-	tokens, error := []token32{e.max}, "\n"
+	tokens, err := []token32{e.max}, "\n"
 	positions, p := make([]int, 2*len(tokens)), 0
 	for _, token := range tokens {
 		positions[p], p = int(token.begin), p+1
@@ -268,14 +279,14 @@ func (e *parseError) Error() string {
 	}
 	for _, token := range tokens {
 		begin, end := int(token.begin), int(token.end)
-		error += fmt.Sprintf(format,
+		err += fmt.Sprintf(format,
 			rul3s[token.pegRule],
 			translations[begin].line, translations[begin].symbol,
 			translations[end].line, translations[end].symbol,
 			strconv.Quote(string(e.p.buffer[begin:end])))
 	}
 
-	return error
+	return err
 }
 
 func (p *Parser) PrintSyntaxTree() {
@@ -286,6 +297,16 @@ func (p *Parser) PrintSyntaxTree() {
 	}
 }
 
+func (p *Parser) WriteSyntaxTree(w io.Writer) {
+	p.tokens32.WriteSyntaxTree(w, p.Buffer)
+}
+
+func (p *Parser) SprintSyntaxTree() string {
+	var bldr strings.Builder
+	p.WriteSyntaxTree(&bldr)
+	return bldr.String()
+}
+
 func (p *Parser) Execute() {
 	buffer, _buffer, text, begin, end := p.Buffer, p.buffer, "", 0, 0
 	for _, token := range p.Tokens() {
@@ -305,6 +326,7 @@ func (p *Parser) Execute() {
 				Name:        text,
 				Columns:     make([]Column, 0),
 				Description: "",
+				Line:        p.lineOf(begin),
 			}
 
 		case ruleAction2:
@@ -323,6 +345,7 @@ func (p *Parser) Execute() {
 
 			p.column = &Column{
 				Name: text,
+				Line: p.lineOf(begin),
 			}
 
 		case ruleAction6:
@@ -349,17 +372,43 @@ func (p *Parser) Execute() {
 
 			p.column.Relation.ColumnName = text
 
+		case ruleAction11:
+
+			p.comments = append(p.comments, Comment{
+				Line: p.lineOf(begin),
+				Text: strings.TrimSpace(text),
+			})
+
 		}
 	}
 	_, _, _, _, _ = buffer, _buffer, text, begin, end
 }
 
-func (p *Parser) Init() {
+func Pretty(pretty bool) func(*Parser) error {
+	return func(p *Parser) error {
+		p.Pretty = pretty
+		return nil
+	}
+}
+
+func Size(size int) func(*Parser) error {
+	return func(p *Parser) error {
+		p.tokens32 = tokens32{tree: make([]token32, 0, size)}
+		return nil
+	}
+}
+func (p *Parser) Init(options ...func(*Parser) error) error {
 	var (
 		max                  token32
 		position, tokenIndex uint32
 		buffer               []rune
 	)
+	for _, option := range options {
+		err := option(p)
+		if err != nil {
+			return err
+		}
+	}
 	p.reset = func() {
 		max = token32{}
 		position, tokenIndex = 0, 0
@@ -373,7 +422,7 @@ func (p *Parser) Init() {
 	p.reset()
 
 	_rules := p.rules
-	tree := tokens32{tree: make([]token32, math.MaxInt16)}
+	tree := p.tokens32
 	p.parse = func(rule ...int) error {
 		r := 1
 		if len(rule) > 0 {
@@ -467,7 +516,7 @@ func (p *Parser) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Sep <- <('\n' / '\t' / ' ')+> */
+		/* 1 Sep <- <('\n' / '\t' / ' ' / Comment)+> */
 		func() bool {
 			position8, tokenIndex8 := position, tokenIndex
 			{
@@ -489,36 +538,48 @@ func (p *Parser) Init() {
 				l14:
 					position, tokenIndex = position12, tokenIndex12
 					if buffer[position] != rune(' ') {
-						goto l8
+						goto l15
 					}
 					position++
+					goto l12
+				l15:
+					position, tokenIndex = position12, tokenIndex12
+					if !_rules[ruleComment]() {
+						goto l8
+					}
 				}
 			l12:
 			l10:
 				{
 					position11, tokenIndex11 := position, tokenIndex
 					{
-						position15, tokenIndex15 := position, tokenIndex
+						position16, tokenIndex16 := position, tokenIndex
 						if buffer[position] != rune('\n') {
-							goto l16
+							goto l17
 						}
 						position++
-						goto l15
-					l16:
-						position, tokenIndex = position15, tokenIndex15
+						goto l16
+					l17:
+						position, tokenIndex = position16, tokenIndex16
 						if buffer[position] != rune('\t') {
-							goto l17
+							goto l18
 						}
 						position++
-						goto l15
-					l17:
-						position, tokenIndex = position15, tokenIndex15
+						goto l16
+					l18:
+						position, tokenIndex = position16, tokenIndex16
 						if buffer[position] != rune(' ') {
-							goto l11
+							goto l19
 						}
 						position++
+						goto l16
+					l19:
+						position, tokenIndex = position16, tokenIndex16
+						if !_rules[ruleComment]() {
+							goto l11
+						}
 					}
-				l15:
+				l16:
 					goto l10
 				l11:
 					position, tokenIndex = position11, tokenIndex11
@@ -532,936 +593,1120 @@ func (p *Parser) Init() {
 		},
 		/* 2 Space <- <' '> */
 		func() bool {
-			position18, tokenIndex18 := position, tokenIndex
+			position20, tokenIndex20 := position, tokenIndex
 			{
-				position19 := position
+				position21 := position
 				if buffer[position] != rune(' ') {
-					goto l18
+					goto l20
 				}
 				position++
-				add(ruleSpace, position19)
+				add(ruleSpace, position21)
 			}
 			return true
-		l18:
-			position, tokenIndex = position18, tokenIndex18
+		l20:
+			position, tokenIndex = position20, tokenIndex20
 			return false
 		},
 		/* 3 TableDef <- <(TableName Sep (':' Space* TableDescription)? LeftBrace Sep Columns Sep RightBrace)> */
 		func() bool {
-			position20, tokenIndex20 := position, tokenIndex
+			position22, tokenIndex22 := position, tokenIndex
 			{
-				position21 := position
+				position23 := position
 				if !_rules[ruleTableName]() {
-					goto l20
+					goto l22
 				}
 				if !_rules[ruleSep]() {
-					goto l20
+					goto l22
 				}
 				{
-					position22, tokenIndex22 := position, tokenIndex
+					position24, tokenIndex24 := position, tokenIndex
 					if buffer[position] != rune(':') {
-						goto l22
+						goto l24
 					}
 					position++
-				l24:
+				l26:
 					{
-						position25, tokenIndex25 := position, tokenIndex
+						position27, tokenIndex27 := position, tokenIndex
 						if !_rules[ruleSpace]() {
-							goto l25
+							goto l27
 						}
-						goto l24
-					l25:
-						position, tokenIndex = position25, tokenIndex25
+						goto l26
+					l27:
+						position, tokenIndex = position27, tokenIndex27
 					}
 					if !_rules[ruleTableDescription]() {
-						goto l22
+						goto l24
 					}
-					goto l23
-				l22:
-					position, tokenIndex = position22, tokenIndex22
+					goto l25
+				l24:
+					position, tokenIndex = position24, tokenIndex24
 				}
-			l23:
+			l25:
 				if !_rules[ruleLeftBrace]() {
-					goto l20
+					goto l22
 				}
 				if !_rules[ruleSep]() {
-					goto l20
+					goto l22
 				}
 				if !_rules[ruleColumns]() {
-					goto l20
+					goto l22
 				}
 				if !_rules[ruleSep]() {
-					goto l20
+					goto l22
 				}
 				if !_rules[ruleRightBrace]() {
-					goto l20
+					goto l22
 				}
-				add(ruleTableDef, position21)
+				add(ruleTableDef, position23)
 			}
 			return true
-		l20:
-			position, tokenIndex = position20, tokenIndex20
+		l22:
+			position, tokenIndex = position22, tokenIndex22
 			return false
 		},
 		/* 4 LeftBrace <- <'{'> */
 		func() bool {
-			position26, tokenIndex26 := position, tokenIndex
+			position28, tokenIndex28 := position, tokenIndex
 			{
-				position27 := position
+				position29 := position
 				if buffer[position] != rune('{') {
-					goto l26
+					goto l28
 				}
 				position++
-				add(ruleLeftBrace, position27)
+				add(ruleLeftBrace, position29)
 			}
 			return true
-		l26:
-			position, tokenIndex = position26, tokenIndex26
+		l28:
+			position, tokenIndex = position28, tokenIndex28
 			return false
 		},
 		/* 5 RightBrace <- <('}' Action0)> */
 		func() bool {
-			position28, tokenIndex28 := position, tokenIndex
+			position30, tokenIndex30 := position, tokenIndex
 			{
-				position29 := position
+				position31 := position
 				if buffer[position] != rune('}') {
-					goto l28
+					goto l30
 				}
 				position++
 				if !_rules[ruleAction0]() {
-					goto l28
+					goto l30
 				}
-				add(ruleRightBrace, position29)
+				add(ruleRightBrace, position31)
 			}
 			return true
-		l28:
-			position, tokenIndex = position28, tokenIndex28
+		l30:
+			position, tokenIndex = position30, tokenIndex30
 			return false
 		},
 		/* 6 TableName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action1)> */
 		func() bool {
-			position30, tokenIndex30 := position, tokenIndex
+			position32, tokenIndex32 := position, tokenIndex
 			{
-				position31 := position
+				position33 := position
 				{
-					position32 := position
+					position34 := position
 					{
-						position35, tokenIndex35 := position, tokenIndex
+						position37, tokenIndex37 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l36
+							goto l38
 						}
 						position++
-						goto l35
-					l36:
-						position, tokenIndex = position35, tokenIndex35
+						goto l37
+					l38:
+						position, tokenIndex = position37, tokenIndex37
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l37
+							goto l39
 						}
 						position++
-						goto l35
-					l37:
-						position, tokenIndex = position35, tokenIndex35
+						goto l37
+					l39:
+						position, tokenIndex = position37, tokenIndex37
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l38
+							goto l40
 						}
 						position++
-						goto l35
-					l38:
-						position, tokenIndex = position35, tokenIndex35
+						goto l37
+					l40:
+						position, tokenIndex = position37, tokenIndex37
 						if buffer[position] != rune('_') {
-							goto l30
+							goto l32
 						}
 						position++
 					}
+				l37:
 				l35:
-				l33:
 					{
-						position34, tokenIndex34 := position, tokenIndex
+						position36, tokenIndex36 := position, tokenIndex
 						{
-							position39, tokenIndex39 := position, tokenIndex
+							position41, tokenIndex41 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l40
+								goto l42
 							}
 							position++
-							goto l39
-						l40:
-							position, tokenIndex = position39, tokenIndex39
+							goto l41
+						l42:
+							position, tokenIndex = position41, tokenIndex41
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l41
+								goto l43
 							}
 							position++
-							goto l39
-						l41:
-							position, tokenIndex = position39, tokenIndex39
+							goto l41
+						l43:
+							position, tokenIndex = position41, tokenIndex41
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l42
+								goto l44
 							}
 							position++
-							goto l39
-						l42:
-							position, tokenIndex = position39, tokenIndex39
+							goto l41
+						l44:
+							position, tokenIndex = position41, tokenIndex41
 							if buffer[position] != rune('_') {
-								goto l34
+								goto l36
 							}
 							position++
 						}
-					l39:
-						goto l33
-					l34:
-						position, tokenIndex = position34, tokenIndex34
+					l41:
+						goto l35
+					l36:
+						position, tokenIndex = position36, tokenIndex36
 					}
-					add(rulePegText, position32)
+					add(rulePegText, position34)
 				}
 				if !_rules[ruleAction1]() {
-					goto l30
+					goto l32
 				}
-				add(ruleTableName, position31)
+				add(ruleTableName, position33)
 			}
 			return true
-		l30:
-			position, tokenIndex = position30, tokenIndex30
+		l32:
+			position, tokenIndex = position32, tokenIndex32
 			return false
 		},
-		/* 7 TableDescription <- <(<(!('\n' / '{') .)+> Action2)> */
+		/* 7 TableDescription <- <(<(Quoted / (!('\n' / '{' / '#') .))+> Action2)> */
 		func() bool {
-			position43, tokenIndex43 := position, tokenIndex
+			position45, tokenIndex45 := position, tokenIndex
 			{
-				position44 := position
+				position46 := position
 				{
-					position45 := position
+					position47 := position
 					{
-						position48, tokenIndex48 := position, tokenIndex
-						{
-							position49, tokenIndex49 := position, tokenIndex
-							if buffer[position] != rune('\n') {
-								goto l50
-							}
-							position++
-							goto l49
-						l50:
-							position, tokenIndex = position49, tokenIndex49
-							if buffer[position] != rune('{') {
-								goto l48
-							}
-							position++
+						position50, tokenIndex50 := position, tokenIndex
+						if !_rules[ruleQuoted]() {
+							goto l51
 						}
-					l49:
-						goto l43
-					l48:
-						position, tokenIndex = position48, tokenIndex48
-					}
-					if !matchDot() {
-						goto l43
-					}
-				l46:
-					{
-						position47, tokenIndex47 := position, tokenIndex
+						goto l50
+					l51:
+						position, tokenIndex = position50, tokenIndex50
 						{
-							position51, tokenIndex51 := position, tokenIndex
+							position52, tokenIndex52 := position, tokenIndex
 							{
-								position52, tokenIndex52 := position, tokenIndex
+								position53, tokenIndex53 := position, tokenIndex
 								if buffer[position] != rune('\n') {
-									goto l53
+									goto l54
 								}
 								position++
-								goto l52
-							l53:
-								position, tokenIndex = position52, tokenIndex52
+								goto l53
+							l54:
+								position, tokenIndex = position53, tokenIndex53
 								if buffer[position] != rune('{') {
-									goto l51
+									goto l55
+								}
+								position++
+								goto l53
+							l55:
+								position, tokenIndex = position53, tokenIndex53
+								if buffer[position] != rune('#') {
+									goto l52
 								}
 								position++
 							}
+						l53:
+							goto l45
 						l52:
-							goto l47
-						l51:
-							position, tokenIndex = position51, tokenIndex51
+							position, tokenIndex = position52, tokenIndex52
 						}
 						if !matchDot() {
-							goto l47
+							goto l45
+						}
+					}
+				l50:
+				l48:
+					{
+						position49, tokenIndex49 := position, tokenIndex
+						{
+							position56, tokenIndex56 := position, tokenIndex
+							if !_rules[ruleQuoted]() {
+								goto l57
+							}
+							goto l56
+						l57:
+							position, tokenIndex = position56, tokenIndex56
+							{
+								position58, tokenIndex58 := position, tokenIndex
+								{
+									position59, tokenIndex59 := position, tokenIndex
+									if buffer[position] != rune('\n') {
+										goto l60
+									}
+									position++
+									goto l59
+								l60:
+									position, tokenIndex = position59, tokenIndex59
+									if buffer[position] != rune('{') {
+										goto l61
+									}
+									position++
+									goto l59
+								l61:
+									position, tokenIndex = position59, tokenIndex59
+									if buffer[position] != rune('#') {
+										goto l58
+									}
+									position++
+								}
+							l59:
+								goto l49
+							l58:
+								position, tokenIndex = position58, tokenIndex58
+							}
+							if !matchDot() {
+								goto l49
+							}
 						}
-						goto l46
-					l47:
-						position, tokenIndex = position47, tokenIndex47
+					l56:
+						goto l48
+					l49:
+						position, tokenIndex = position49, tokenIndex49
 					}
-					add(rulePegText, position45)
+					add(rulePegText, position47)
 				}
 				if !_rules[ruleAction2]() {
-					goto l43
+					goto l45
 				}
-				add(ruleTableDescription, position44)
+				add(ruleTableDescription, position46)
 			}
 			return true
-		l43:
-			position, tokenIndex = position43, tokenIndex43
+		l45:
+			position, tokenIndex = position45, tokenIndex45
 			return false
 		},
 		/* 8 Columns <- <(Column (Sep Column)*)> */
 		func() bool {
-			position54, tokenIndex54 := position, tokenIndex
+			position62, tokenIndex62 := position, tokenIndex
 			{
-				position55 := position
+				position63 := position
 				if !_rules[ruleColumn]() {
-					goto l54
+					goto l62
 				}
-			l56:
+			l64:
 				{
-					position57, tokenIndex57 := position, tokenIndex
+					position65, tokenIndex65 := position, tokenIndex
 					if !_rules[ruleSep]() {
-						goto l57
+						goto l65
 					}
 					if !_rules[ruleColumn]() {
-						goto l57
+						goto l65
 					}
-					goto l56
-				l57:
-					position, tokenIndex = position57, tokenIndex57
+					goto l64
+				l65:
+					position, tokenIndex = position65, tokenIndex65
 				}
-				add(ruleColumns, position55)
+				add(ruleColumns, position63)
 			}
 			return true
-		l54:
-			position, tokenIndex = position54, tokenIndex54
+		l62:
+			position, tokenIndex = position62, tokenIndex62
 			return false
 		},
 		/* 9 Column <- <(ColumnDef Space* (RightArrow Sep TargetTableName dot TargetColumnName Space*)? (':' Space* ColumnDescription)? Action3)> */
 		func() bool {
-			position58, tokenIndex58 := position, tokenIndex
+			position66, tokenIndex66 := position, tokenIndex
 			{
-				position59 := position
+				position67 := position
 				if !_rules[ruleColumnDef]() {
-					goto l58
+					goto l66
 				}
-			l60:
+			l68:
 				{
-					position61, tokenIndex61 := position, tokenIndex
+					position69, tokenIndex69 := position, tokenIndex
 					if !_rules[ruleSpace]() {
-						goto l61
+						goto l69
 					}
-					goto l60
-				l61:
-					position, tokenIndex = position61, tokenIndex61
+					goto l68
+				l69:
+					position, tokenIndex = position69, tokenIndex69
 				}
 				{
-					position62, tokenIndex62 := position, tokenIndex
+					position70, tokenIndex70 := position, tokenIndex
 					if !_rules[ruleRightArrow]() {
-						goto l62
+						goto l70
 					}
 					if !_rules[ruleSep]() {
-						goto l62
+						goto l70
 					}
 					if !_rules[ruleTargetTableName]() {
-						goto l62
+						goto l70
 					}
 					if !_rules[ruledot]() {
-						goto l62
+						goto l70
 					}
 					if !_rules[ruleTargetColumnName]() {
-						goto l62
+						goto l70
 					}
-				l64:
+				l72:
 					{
-						position65, tokenIndex65 := position, tokenIndex
+						position73, tokenIndex73 := position, tokenIndex
 						if !_rules[ruleSpace]() {
-							goto l65
+							goto l73
 						}
-						goto l64
-					l65:
-						position, tokenIndex = position65, tokenIndex65
+						goto l72
+					l73:
+						position, tokenIndex = position73, tokenIndex73
 					}
-					goto l63
-				l62:
-					position, tokenIndex = position62, tokenIndex62
+					goto l71
+				l70:
+					position, tokenIndex = position70, tokenIndex70
 				}
-			l63:
+			l71:
 				{
-					position66, tokenIndex66 := position, tokenIndex
+					position74, tokenIndex74 := position, tokenIndex
 					if buffer[position] != rune(':') {
-						goto l66
+						goto l74
 					}
 					position++
-				l68:
+				l76:
 					{
-						position69, tokenIndex69 := position, tokenIndex
+						position77, tokenIndex77 := position, tokenIndex
 						if !_rules[ruleSpace]() {
-							goto l69
+							goto l77
 						}
-						goto l68
-					l69:
-						position, tokenIndex = position69, tokenIndex69
+						goto l76
+					l77:
+						position, tokenIndex = position77, tokenIndex77
 					}
 					if !_rules[ruleColumnDescription]() {
-						goto l66
+						goto l74
 					}
-					goto l67
-				l66:
-					position, tokenIndex = position66, tokenIndex66
+					goto l75
+				l74:
+					position, tokenIndex = position74, tokenIndex74
 				}
-			l67:
+			l75:
 				if !_rules[ruleAction3]() {
-					goto l58
+					goto l66
 				}
-				add(ruleColumn, position59)
+				add(ruleColumn, position67)
 			}
 			return true
-		l58:
-			position, tokenIndex = position58, tokenIndex58
+		l66:
+			position, tokenIndex = position66, tokenIndex66
 			return false
 		},
-		/* 10 ColumnDescription <- <(<(!'\n' .)+> Action4)> */
+		/* 10 ColumnDescription <- <(<(Quoted / (!('\n' / '#') .))+> Action4)> */
 		func() bool {
-			position70, tokenIndex70 := position, tokenIndex
+			position78, tokenIndex78 := position, tokenIndex
 			{
-				position71 := position
+				position79 := position
 				{
-					position72 := position
+					position80 := position
 					{
-						position75, tokenIndex75 := position, tokenIndex
-						if buffer[position] != rune('\n') {
-							goto l75
+						position83, tokenIndex83 := position, tokenIndex
+						if !_rules[ruleQuoted]() {
+							goto l84
+						}
+						goto l83
+					l84:
+						position, tokenIndex = position83, tokenIndex83
+						{
+							position85, tokenIndex85 := position, tokenIndex
+							{
+								position86, tokenIndex86 := position, tokenIndex
+								if buffer[position] != rune('\n') {
+									goto l87
+								}
+								position++
+								goto l86
+							l87:
+								position, tokenIndex = position86, tokenIndex86
+								if buffer[position] != rune('#') {
+									goto l85
+								}
+								position++
+							}
+						l86:
+							goto l78
+						l85:
+							position, tokenIndex = position85, tokenIndex85
+						}
+						if !matchDot() {
+							goto l78
 						}
-						position++
-						goto l70
-					l75:
-						position, tokenIndex = position75, tokenIndex75
 					}
-					if !matchDot() {
-						goto l70
+				l83:
+				l81:
+					{
+						position82, tokenIndex82 := position, tokenIndex
+						{
+							position88, tokenIndex88 := position, tokenIndex
+							if !_rules[ruleQuoted]() {
+								goto l89
+							}
+							goto l88
+						l89:
+							position, tokenIndex = position88, tokenIndex88
+							{
+								position90, tokenIndex90 := position, tokenIndex
+								{
+									position91, tokenIndex91 := position, tokenIndex
+									if buffer[position] != rune('\n') {
+										goto l92
+									}
+									position++
+									goto l91
+								l92:
+									position, tokenIndex = position91, tokenIndex91
+									if buffer[position] != rune('#') {
+										goto l90
+									}
+									position++
+								}
+							l91:
+								goto l82
+							l90:
+								position, tokenIndex = position90, tokenIndex90
+							}
+							if !matchDot() {
+								goto l82
+							}
+						}
+					l88:
+						goto l81
+					l82:
+						position, tokenIndex = position82, tokenIndex82
 					}
-				l73:
+					add(rulePegText, position80)
+				}
+				if !_rules[ruleAction4]() {
+					goto l78
+				}
+				add(ruleColumnDescription, position79)
+			}
+			return true
+		l78:
+			position, tokenIndex = position78, tokenIndex78
+			return false
+		},
+		/* 11 Quoted <- <('"' (!('"' / '\n') .)* '"')> */
+		func() bool {
+			position93, tokenIndex93 := position, tokenIndex
+			{
+				position94 := position
+				if buffer[position] != rune('"') {
+					goto l93
+				}
+				position++
+			l95:
+				{
+					position96, tokenIndex96 := position, tokenIndex
 					{
-						position74, tokenIndex74 := position, tokenIndex
+						position97, tokenIndex97 := position, tokenIndex
 						{
-							position76, tokenIndex76 := position, tokenIndex
+							position98, tokenIndex98 := position, tokenIndex
+							if buffer[position] != rune('"') {
+								goto l99
+							}
+							position++
+							goto l98
+						l99:
+							position, tokenIndex = position98, tokenIndex98
 							if buffer[position] != rune('\n') {
-								goto l76
+								goto l97
 							}
 							position++
-							goto l74
-						l76:
-							position, tokenIndex = position76, tokenIndex76
-						}
-						if !matchDot() {
-							goto l74
 						}
-						goto l73
-					l74:
-						position, tokenIndex = position74, tokenIndex74
+					l98:
+						goto l96
+					l97:
+						position, tokenIndex = position97, tokenIndex97
+					}
+					if !matchDot() {
+						goto l96
 					}
-					add(rulePegText, position72)
+					goto l95
+				l96:
+					position, tokenIndex = position96, tokenIndex96
 				}
-				if !_rules[ruleAction4]() {
-					goto l70
+				if buffer[position] != rune('"') {
+					goto l93
 				}
-				add(ruleColumnDescription, position71)
+				position++
+				add(ruleQuoted, position94)
 			}
 			return true
-		l70:
-			position, tokenIndex = position70, tokenIndex70
+		l93:
+			position, tokenIndex = position93, tokenIndex93
 			return false
 		},
-		/* 11 dot <- <'.'> */
+		/* 12 dot <- <'.'> */
 		func() bool {
-			position77, tokenIndex77 := position, tokenIndex
+			position100, tokenIndex100 := position, tokenIndex
 			{
-				position78 := position
+				position101 := position
 				if buffer[position] != rune('.') {
-					goto l77
+					goto l100
 				}
 				position++
-				add(ruledot, position78)
+				add(ruledot, position101)
 			}
 			return true
-		l77:
-			position, tokenIndex = position77, tokenIndex77
+		l100:
+			position, tokenIndex = position100, tokenIndex100
 			return false
 		},
-		/* 12 ColumnName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action5)> */
+		/* 13 ColumnName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action5)> */
 		func() bool {
-			position79, tokenIndex79 := position, tokenIndex
+			position102, tokenIndex102 := position, tokenIndex
 			{
-				position80 := position
+				position103 := position
 				{
-					position81 := position
+					position104 := position
 					{
-						position84, tokenIndex84 := position, tokenIndex
+						position107, tokenIndex107 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l85
+							goto l108
 						}
 						position++
-						goto l84
-					l85:
-						position, tokenIndex = position84, tokenIndex84
+						goto l107
+					l108:
+						position, tokenIndex = position107, tokenIndex107
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l86
+							goto l109
 						}
 						position++
-						goto l84
-					l86:
-						position, tokenIndex = position84, tokenIndex84
+						goto l107
+					l109:
+						position, tokenIndex = position107, tokenIndex107
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l87
+							goto l110
 						}
 						position++
-						goto l84
-					l87:
-						position, tokenIndex = position84, tokenIndex84
+						goto l107
+					l110:
+						position, tokenIndex = position107, tokenIndex107
 						if buffer[position] != rune('_') {
-							goto l79
+							goto l102
 						}
 						position++
 					}
-				l84:
-				l82:
+				l107:
+				l105:
 					{
-						position83, tokenIndex83 := position, tokenIndex
+						position106, tokenIndex106 := position, tokenIndex
 						{
-							position88, tokenIndex88 := position, tokenIndex
+							position111, tokenIndex111 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l89
+								goto l112
 							}
 							position++
-							goto l88
-						l89:
-							position, tokenIndex = position88, tokenIndex88
+							goto l111
+						l112:
+							position, tokenIndex = position111, tokenIndex111
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l90
+								goto l113
 							}
 							position++
-							goto l88
-						l90:
-							position, tokenIndex = position88, tokenIndex88
+							goto l111
+						l113:
+							position, tokenIndex = position111, tokenIndex111
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l91
+								goto l114
 							}
 							position++
-							goto l88
-						l91:
-							position, tokenIndex = position88, tokenIndex88
+							goto l111
+						l114:
+							position, tokenIndex = position111, tokenIndex111
 							if buffer[position] != rune('_') {
-								goto l83
+								goto l106
 							}
 							position++
 						}
-					l88:
-						goto l82
-					l83:
-						position, tokenIndex = position83, tokenIndex83
+					l111:
+						goto l105
+					l106:
+						position, tokenIndex = position106, tokenIndex106
 					}
-					add(rulePegText, position81)
+					add(rulePegText, position104)
 				}
 				if !_rules[ruleAction5]() {
-					goto l79
+					goto l102
 				}
-				add(ruleColumnName, position80)
+				add(ruleColumnName, position103)
 			}
 			return true
-		l79:
-			position, tokenIndex = position79, tokenIndex79
+		l102:
+			position, tokenIndex = position102, tokenIndex102
 			return false
 		},
-		/* 13 ColumnDef <- <(ColumnName (Space* ColumnType)?)> */
+		/* 14 ColumnDef <- <(ColumnName (Space* ColumnType)?)> */
 		func() bool {
-			position92, tokenIndex92 := position, tokenIndex
+			position115, tokenIndex115 := position, tokenIndex
 			{
-				position93 := position
+				position116 := position
 				if !_rules[ruleColumnName]() {
-					goto l92
+					goto l115
 				}
 				{
-					position94, tokenIndex94 := position, tokenIndex
-				l96:
+					position117, tokenIndex117 := position, tokenIndex
+				l119:
 					{
-						position97, tokenIndex97 := position, tokenIndex
+						position120, tokenIndex120 := position, tokenIndex
 						if !_rules[ruleSpace]() {
-							goto l97
+							goto l120
 						}
-						goto l96
-					l97:
-						position, tokenIndex = position97, tokenIndex97
+						goto l119
+					l120:
+						position, tokenIndex = position120, tokenIndex120
 					}
 					if !_rules[ruleColumnType]() {
-						goto l94
+						goto l117
 					}
-					goto l95
-				l94:
-					position, tokenIndex = position94, tokenIndex94
+					goto l118
+				l117:
+					position, tokenIndex = position117, tokenIndex117
 				}
-			l95:
-				add(ruleColumnDef, position93)
+			l118:
+				add(ruleColumnDef, position116)
 			}
 			return true
-		l92:
-			position, tokenIndex = position92, tokenIndex92
+		l115:
+			position, tokenIndex = position115, tokenIndex115
 			return false
 		},
-		/* 14 RightArrow <- <(RightDotArrow / RightLineArrow)> */
+		/* 15 RightArrow <- <(RightDotArrow / RightLineArrow)> */
 		func() bool {
-			position98, tokenIndex98 := position, tokenIndex
+			position121, tokenIndex121 := position, tokenIndex
 			{
-				position99 := position
+				position122 := position
 				{
-					position100, tokenIndex100 := position, tokenIndex
+					position123, tokenIndex123 := position, tokenIndex
 					if !_rules[ruleRightDotArrow]() {
-						goto l101
+						goto l124
 					}
-					goto l100
-				l101:
-					position, tokenIndex = position100, tokenIndex100
+					goto l123
+				l124:
+					position, tokenIndex = position123, tokenIndex123
 					if !_rules[ruleRightLineArrow]() {
-						goto l98
+						goto l121
 					}
 				}
-			l100:
-				add(ruleRightArrow, position99)
+			l123:
+				add(ruleRightArrow, position122)
 			}
 			return true
-		l98:
-			position, tokenIndex = position98, tokenIndex98
+		l121:
+			position, tokenIndex = position121, tokenIndex121
 			return false
 		},
-		/* 15 ColumnType <- <(<(!('-' / ':' / '.' / '\n') .)+> Action6)> */
+		/* 16 ColumnType <- <(<(!('-' / ':' / '.' / '\n' / '#') .)+> Action6)> */
 		func() bool {
-			position102, tokenIndex102 := position, tokenIndex
+			position125, tokenIndex125 := position, tokenIndex
 			{
-				position103 := position
+				position126 := position
 				{
-					position104 := position
+					position127 := position
 					{
-						position107, tokenIndex107 := position, tokenIndex
+						position130, tokenIndex130 := position, tokenIndex
 						{
-							position108, tokenIndex108 := position, tokenIndex
+							position131, tokenIndex131 := position, tokenIndex
 							if buffer[position] != rune('-') {
-								goto l109
+								goto l132
 							}
 							position++
-							goto l108
-						l109:
-							position, tokenIndex = position108, tokenIndex108
+							goto l131
+						l132:
+							position, tokenIndex = position131, tokenIndex131
 							if buffer[position] != rune(':') {
-								goto l110
+								goto l133
 							}
 							position++
-							goto l108
-						l110:
-							position, tokenIndex = position108, tokenIndex108
+							goto l131
+						l133:
+							position, tokenIndex = position131, tokenIndex131
 							if buffer[position] != rune('.') {
-								goto l111
+								goto l134
 							}
 							position++
-							goto l108
-						l111:
-							position, tokenIndex = position108, tokenIndex108
+							goto l131
+						l134:
+							position, tokenIndex = position131, tokenIndex131
 							if buffer[position] != rune('\n') {
-								goto l107
+								goto l135
+							}
+							position++
+							goto l131
+						l135:
+							position, tokenIndex = position131, tokenIndex131
+							if buffer[position] != rune('#') {
+								goto l130
 							}
 							position++
 						}
-					l108:
-						goto l102
-					l107:
-						position, tokenIndex = position107, tokenIndex107
+					l131:
+						goto l125
+					l130:
+						position, tokenIndex = position130, tokenIndex130
 					}
 					if !matchDot() {
-						goto l102
+						goto l125
 					}
-				l105:
+				l128:
 					{
-						position106, tokenIndex106 := position, tokenIndex
+						position129, tokenIndex129 := position, tokenIndex
 						{
-							position112, tokenIndex112 := position, tokenIndex
+							position136, tokenIndex136 := position, tokenIndex
 							{
-								position113, tokenIndex113 := position, tokenIndex
+								position137, tokenIndex137 := position, tokenIndex
 								if buffer[position] != rune('-') {
-									goto l114
+									goto l138
 								}
 								position++
-								goto l113
-							l114:
-								position, tokenIndex = position113, tokenIndex113
+								goto l137
+							l138:
+								position, tokenIndex = position137, tokenIndex137
 								if buffer[position] != rune(':') {
-									goto l115
+									goto l139
 								}
 								position++
-								goto l113
-							l115:
-								position, tokenIndex = position113, tokenIndex113
+								goto l137
+							l139:
+								position, tokenIndex = position137, tokenIndex137
 								if buffer[position] != rune('.') {
-									goto l116
+									goto l140
 								}
 								position++
-								goto l113
-							l116:
-								position, tokenIndex = position113, tokenIndex113
+								goto l137
+							l140:
+								position, tokenIndex = position137, tokenIndex137
 								if buffer[position] != rune('\n') {
-									goto l112
+									goto l141
+								}
+								position++
+								goto l137
+							l141:
+								position, tokenIndex = position137, tokenIndex137
+								if buffer[position] != rune('#') {
+									goto l136
 								}
 								position++
 							}
-						l113:
-							goto l106
-						l112:
-							position, tokenIndex = position112, tokenIndex112
+						l137:
+							goto l129
+						l136:
+							position, tokenIndex = position136, tokenIndex136
 						}
 						if !matchDot() {
-							goto l106
+							goto l129
 						}
-						goto l105
-					l106:
-						position, tokenIndex = position106, tokenIndex106
+						goto l128
+					l129:
+						position, tokenIndex = position129, tokenIndex129
 					}
-					add(rulePegText, position104)
+					add(rulePegText, position127)
 				}
 				if !_rules[ruleAction6]() {
-					goto l102
+					goto l125
 				}
-				add(ruleColumnType, position103)
+				add(ruleColumnType, position126)
 			}
 			return true
-		l102:
-			position, tokenIndex = position102, tokenIndex102
+		l125:
+			position, tokenIndex = position125, tokenIndex125
 			return false
 		},
-		/* 16 RightDotArrow <- <('.' '.' '>' Action7)> */
+		/* 17 RightDotArrow <- <('.' '.' '>' Action7)> */
 		func() bool {
-			position117, tokenIndex117 := position, tokenIndex
+			position142, tokenIndex142 := position, tokenIndex
 			{
-				position118 := position
+				position143 := position
 				if buffer[position] != rune('.') {
-					goto l117
+					goto l142
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l117
+					goto l142
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l117
+					goto l142
 				}
 				position++
 				if !_rules[ruleAction7]() {
-					goto l117
+					goto l142
 				}
-				add(ruleRightDotArrow, position118)
+				add(ruleRightDotArrow, position143)
 			}
 			return true
-		l117:
-			position, tokenIndex = position117, tokenIndex117
+		l142:
+			position, tokenIndex = position142, tokenIndex142
 			return false
 		},
-		/* 17 RightLineArrow <- <('-' '>' Action8)> */
+		/* 18 RightLineArrow <- <('-' '>' Action8)> */
 		func() bool {
-			position119, tokenIndex119 := position, tokenIndex
+			position144, tokenIndex144 := position, tokenIndex
 			{
-				position120 := position
+				position145 := position
 				if buffer[position] != rune('-') {
-					goto l119
+					goto l144
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l119
+					goto l144
 				}
 				position++
 				if !_rules[ruleAction8]() {
-					goto l119
+					goto l144
 				}
-				add(ruleRightLineArrow, position120)
+				add(ruleRightLineArrow, position145)
 			}
 			return true
-		l119:
-			position, tokenIndex = position119, tokenIndex119
+		l144:
+			position, tokenIndex = position144, tokenIndex144
 			return false
 		},
-		/* 18 TargetTableName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action9)> */
+		/* 19 TargetTableName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action9)> */
 		func() bool {
-			position121, tokenIndex121 := position, tokenIndex
+			position146, tokenIndex146 := position, tokenIndex
 			{
-				position122 := position
+				position147 := position
 				{
-					position123 := position
+					position148 := position
 					{
-						position126, tokenIndex126 := position, tokenIndex
+						position151, tokenIndex151 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l127
+							goto l152
 						}
 						position++
-						goto l126
-					l127:
-						position, tokenIndex = position126, tokenIndex126
+						goto l151
+					l152:
+						position, tokenIndex = position151, tokenIndex151
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l128
+							goto l153
 						}
 						position++
-						goto l126
-					l128:
-						position, tokenIndex = position126, tokenIndex126
+						goto l151
+					l153:
+						position, tokenIndex = position151, tokenIndex151
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l129
+							goto l154
 						}
 						position++
-						goto l126
-					l129:
-						position, tokenIndex = position126, tokenIndex126
+						goto l151
+					l154:
+						position, tokenIndex = position151, tokenIndex151
 						if buffer[position] != rune('_') {
-							goto l121
+							goto l146
 						}
 						position++
 					}
-				l126:
-				l124:
+				l151:
+				l149:
 					{
-						position125, tokenIndex125 := position, tokenIndex
+						position150, tokenIndex150 := position, tokenIndex
 						{
-							position130, tokenIndex130 := position, tokenIndex
+							position155, tokenIndex155 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l131
+								goto l156
 							}
 							position++
-							goto l130
-						l131:
-							position, tokenIndex = position130, tokenIndex130
+							goto l155
+						l156:
+							position, tokenIndex = position155, tokenIndex155
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l132
+								goto l157
 							}
 							position++
-							goto l130
-						l132:
-							position, tokenIndex = position130, tokenIndex130
+							goto l155
+						l157:
+							position, tokenIndex = position155, tokenIndex155
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l133
+								goto l158
 							}
 							position++
-							goto l130
-						l133:
-							position, tokenIndex = position130, tokenIndex130
+							goto l155
+						l158:
+							position, tokenIndex = position155, tokenIndex155
 							if buffer[position] != rune('_') {
-								goto l125
+								goto l150
 							}
 							position++
 						}
-					l130:
-						goto l124
-					l125:
-						position, tokenIndex = position125, tokenIndex125
+					l155:
+						goto l149
+					l150:
+						position, tokenIndex = position150, tokenIndex150
 					}
-					add(rulePegText, position123)
+					add(rulePegText, position148)
 				}
 				if !_rules[ruleAction9]() {
-					goto l121
+					goto l146
 				}
-				add(ruleTargetTableName, position122)
+				add(ruleTargetTableName, position147)
 			}
 			return true
-		l121:
-			position, tokenIndex = position121, tokenIndex121
+		l146:
+			position, tokenIndex = position146, tokenIndex146
 			return false
 		},
-		/* 19 TargetColumnName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action10)> */
+		/* 20 TargetColumnName <- <(<([a-z] / [A-Z] / [0-9] / '_')+> Action10)> */
 		func() bool {
-			position134, tokenIndex134 := position, tokenIndex
+			position159, tokenIndex159 := position, tokenIndex
 			{
-				position135 := position
+				position160 := position
 				{
-					position136 := position
+					position161 := position
 					{
-						position139, tokenIndex139 := position, tokenIndex
+						position164, tokenIndex164 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l140
+							goto l165
 						}
 						position++
-						goto l139
-					l140:
-						position, tokenIndex = position139, tokenIndex139
+						goto l164
+					l165:
+						position, tokenIndex = position164, tokenIndex164
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l141
+							goto l166
 						}
 						position++
-						goto l139
-					l141:
-						position, tokenIndex = position139, tokenIndex139
+						goto l164
+					l166:
+						position, tokenIndex = position164, tokenIndex164
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l142
+							goto l167
 						}
 						position++
-						goto l139
-					l142:
-						position, tokenIndex = position139, tokenIndex139
+						goto l164
+					l167:
+						position, tokenIndex = position164, tokenIndex164
 						if buffer[position] != rune('_') {
-							goto l134
+							goto l159
 						}
 						position++
 					}
-				l139:
-				l137:
+				l164:
+				l162:
 					{
-						position138, tokenIndex138 := position, tokenIndex
+						position163, tokenIndex163 := position, tokenIndex
 						{
-							position143, tokenIndex143 := position, tokenIndex
+							position168, tokenIndex168 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l144
+								goto l169
 							}
 							position++
-							goto l143
-						l144:
-							position, tokenIndex = position143, tokenIndex143
+							goto l168
+						l169:
+							position, tokenIndex = position168, tokenIndex168
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l145
+								goto l170
 							}
 							position++
-							goto l143
-						l145:
-							position, tokenIndex = position143, tokenIndex143
+							goto l168
+						l170:
+							position, tokenIndex = position168, tokenIndex168
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l146
+								goto l171
 							}
 							position++
-							goto l143
-						l146:
-							position, tokenIndex = position143, tokenIndex143
+							goto l168
+						l171:
+							position, tokenIndex = position168, tokenIndex168
 							if buffer[position] != rune('_') {
-								goto l138
+								goto l163
 							}
 							position++
 						}
-					l143:
-						goto l137
-					l138:
-						position, tokenIndex = position138, tokenIndex138
+					l168:
+						goto l162
+					l163:
+						position, tokenIndex = position163, tokenIndex163
 					}
-					add(rulePegText, position136)
+					add(rulePegText, position161)
 				}
 				if !_rules[ruleAction10]() {
-					goto l134
+					goto l159
 				}
-				add(ruleTargetColumnName, position135)
+				add(ruleTargetColumnName, position160)
 			}
 			return true
-		l134:
-			position, tokenIndex = position134, tokenIndex134
+		l159:
+			position, tokenIndex = position159, tokenIndex159
 			return false
 		},
-		/* 20 EOT <- <!.> */
+		/* 21 EOT <- <!.> */
 		func() bool {
-			position147, tokenIndex147 := position, tokenIndex
+			position172, tokenIndex172 := position, tokenIndex
 			{
-				position148 := position
+				position173 := position
 				{
-					position149, tokenIndex149 := position, tokenIndex
+					position174, tokenIndex174 := position, tokenIndex
 					if !matchDot() {
-						goto l149
+						goto l174
 					}
-					goto l147
-				l149:
-					position, tokenIndex = position149, tokenIndex149
+					goto l172
+				l174:
+					position, tokenIndex = position174, tokenIndex174
 				}
-				add(ruleEOT, position148)
+				add(ruleEOT, position173)
 			}
 			return true
-		l147:
-			position, tokenIndex = position147, tokenIndex147
+		l172:
+			position, tokenIndex = position172, tokenIndex172
 			return false
 		},
-		/* 22 Action0 <- <{
+		/* 22 Comment <- <('#' <(!'\n' .)*> Action11)> */
+		func() bool {
+			position175, tokenIndex175 := position, tokenIndex
+			{
+				position176 := position
+				if buffer[position] != rune('#') {
+					goto l175
+				}
+				position++
+				{
+					position177 := position
+				l178:
+					{
+						position179, tokenIndex179 := position, tokenIndex
+						{
+							position180, tokenIndex180 := position, tokenIndex
+							if buffer[position] != rune('\n') {
+								goto l180
+							}
+							position++
+							goto l179
+						l180:
+							position, tokenIndex = position180, tokenIndex180
+						}
+						if !matchDot() {
+							goto l179
+						}
+						goto l178
+					l179:
+						position, tokenIndex = position179, tokenIndex179
+					}
+					add(rulePegText, position177)
+				}
+				if !_rules[ruleAction11]() {
+					goto l175
+				}
+				add(ruleComment, position176)
+			}
+			return true
+		l175:
+			position, tokenIndex = position175, tokenIndex175
+			return false
+		},
+		/* 24 Action0 <- <{
 		    p.tables = append(p.tables, *p.table)
 		}> */
 		func() bool {
@@ -1471,11 +1716,12 @@ func (p *Parser) Init() {
 			return true
 		},
 		nil,
-		/* 24 Action1 <- <{
+		/* 26 Action1 <- <{
 		    p.table = &Table{
 		        Name: text,
 		        Columns: make([]Column, 0),
 		        Description: "",
+		        Line: p.lineOf(begin),
 			   }
 		}> */
 		func() bool {
@@ -1484,7 +1730,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 25 Action2 <- <{
+		/* 27 Action2 <- <{
 		    p.table.Description = strings.TrimSpace(text)
 		}> */
 		func() bool {
@@ -1493,7 +1739,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 26 Action3 <- <{
+		/* 28 Action3 <- <{
 		    p.table.Columns = append(p.table.Columns, *p.column)
 		}> */
 		func() bool {
@@ -1502,7 +1748,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 27 Action4 <- <{
+		/* 29 Action4 <- <{
 		    p.column.Description = strings.TrimSpace(text)
 		}> */
 		func() bool {
@@ -1511,9 +1757,10 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 28 Action5 <- <{
+		/* 30 Action5 <- <{
 			p.column = &Column{
 			  Name: text,
+			  Line: p.lineOf(begin),
 			}
 		}> */
 		func() bool {
@@ -1522,7 +1769,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 29 Action6 <- <{
+		/* 31 Action6 <- <{
 		    p.column.Type = strings.TrimSpace(text)
 		}> */
 		func() bool {
@@ -1531,7 +1778,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 30 Action7 <- <{
+		/* 32 Action7 <- <{
 		    p.column.Relation = &Relation{
 		        LineType: DotLine,
 		    }
@@ -1542,7 +1789,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 31 Action8 <- <{
+		/* 33 Action8 <- <{
 		    p.column.Relation = &Relation{
 		        LineType: NormalLine,
 		    }
@@ -1553,7 +1800,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 32 Action9 <- <{
+		/* 34 Action9 <- <{
 		    p.column.Relation.TableName = text
 		}> */
 		func() bool {
@@ -1562,7 +1809,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 33 Action10 <- <{
+		/* 35 Action10 <- <{
 		    p.column.Relation.ColumnName = text
 		}> */
 		func() bool {
@@ -1571,6 +1818,19 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
+		/* 36 Action11 <- <{
+		    p.comments = append(p.comments, Comment{
+		        Line: p.lineOf(begin),
+		        Text: strings.TrimSpace(text),
+		    })
+		}> */
+		func() bool {
+			{
+				add(ruleAction11, position)
+			}
+			return true
+		},
 	}
 	p.rules = _rules
+	return nil
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// asciiPad pads s with spaces to take width cells.
+func asciiPad(s string, width int) string {
+	return s + strings.Repeat(" ", width-textCells(s))
+}
+
+func asciiRule(left, middle, right string, widths []int) string {
+	var parts []string
+	for _, w := range widths {
+		parts = append(parts, strings.Repeat("─", w+2))
+	}
+	return left + strings.Join(parts, middle) + right + "\n"
+}
+
+func asciiArrow(r *Relation) string {
+	if r.LineType == DotLine {
+		return "┄▶"
+	}
+	return "─▶"
+}
+
+// asciiTable draws a table with box-drawing characters: its name and
+// description above a row per column. The type, reference and description
+// fields are left out when no column has them.
+func asciiTable(t Table) string {
+	oneLine := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+
+	rows := make([][]string, len(t.Columns))
+	present := []bool{true, false, false, false}
+	for i, c := range t.Columns {
+		reference := ""
+		if c.Relation != nil {
+			reference = asciiArrow(c.Relation) + " " + c.Relation.TableName + "." + c.Relation.ColumnName
+		}
+		rows[i] = []string{c.Name, oneLine(c.Type), reference, oneLine(c.Description)}
+		for j, field := range rows[i] {
+			if field != "" {
+				present[j] = true
+			}
+		}
+	}
+
+	widths := []int{}
+	for i := range rows {
+		var fields []string
+		for j, field := range rows[i] {
+			if present[j] {
+				fields = append(fields, field)
+			}
+		}
+		rows[i] = fields
+	}
+	for j := 0; len(rows) > 0 && j < len(rows[0]); j++ {
+		width := 0
+		for _, row := range rows {
+			if w := textCells(row[j]); w > width {
+				width = w
+			}
+		}
+		widths = append(widths, width)
+	}
+
+	header := []string{t.Name}
+	if t.Description != "" {
+		header = append(header, oneLine(t.Description))
+	}
+	inner := 0
+	for j, w := range widths {
+		if j > 0 {
+			inner += 3
+		}
+		inner += w
+	}
+	for _, line := range header {
+		if w := textCells(line); w > inner {
+			if len(widths) > 0 {
+				widths[len(widths)-1] += w - inner
+			}
+			inner = w
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("┌" + strings.Repeat("─", inner+2) + "┐\n")
+	for _, line := range header {
+		buf.WriteString("│ " + asciiPad(line, inner) + " │\n")
+	}
+	if len(rows) == 0 {
+		buf.WriteString("└" + strings.Repeat("─", inner+2) + "┘\n")
+		return buf.String()
+	}
+	buf.WriteString(asciiRule("├", "┬", "┤", widths))
+	for _, row := range rows {
+		var cells []string
+		for j, field := range row {
+			cells = append(cells, asciiPad(field, widths[j]))
+		}
+		buf.WriteString("│ " + strings.Join(cells, " │ ") + " │\n")
+	}
+	buf.WriteString(asciiRule("└", "┴", "┘", widths))
+	return buf.String()
+}
+
+// ExportASCII draws the tables for a terminal with box-drawing characters,
+// followed by the list of relations.
+func ExportASCII(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	for i, t := range p.Tables() {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(asciiTable(t))
+	}
+
+	var relations []string
+	for _, t := range p.Tables() {
+		for _, c := range t.ColumnsWithRelation() {
+			relations = append(relations, "  "+t.Name+"."+c.Name+" "+asciiArrow(c.Relation)+" "+c.Relation.TableName+"."+c.Relation.ColumnName+"\n")
+		}
+	}
+	if len(relations) > 0 {
+		buf.WriteString("\nRelations:\n")
+		buf.WriteString(strings.Join(relations, ""))
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportASCII(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportASCII(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.txt", buf.Bytes()))
+	})
+
+	Convey("Wide characters are aligned", t, func() {
+		err, parser := parse(t, `
+users : ユーザー一覧 {
+  id BIGINT
+  name : 氏名
+  blog_id ..> blogs.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportASCII(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `┌───────────────────────────────────────┐
+│ users                                 │
+│ ユーザー一覧                          │
+├─────────┬────────┬─────────────┬──────┤
+│ id      │ BIGINT │             │      │
+│ name    │        │             │ 氏名 │
+│ blog_id │        │ ┄▶ blogs.id │      │
+└─────────┴────────┴─────────────┴──────┘
+
+Relations:
+  users.blog_id ┄▶ blogs.id
+`)
+	})
+
+	Convey("Every line of a table has the same width", t, func() {
+		table := asciiTable(Table{Name: "長いテーブル名前のテーブル", Columns: []Column{{Name: "id"}}})
+		lines := strings.Split(strings.TrimSpace(table), "\n")
+		for _, line := range lines {
+			So(textCells(line), ShouldEqual, textCells(lines[0]))
+		}
+	})
+}
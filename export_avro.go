@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// AvroTypes is the default mapping of column types to Avro types. A type is
+// the name of a primitive type or a JSON schema, like a logical type. The
+// precision and scale of a decimal are taken from the column type, like
+// decimal(10, 2), or else are those of the BigQuery NUMERIC.
+var AvroTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "int",
+		"int":              "int",
+		"serial":           "int",
+		"bigint":           "long",
+		"bigserial":        "long",
+		"boolean":          "boolean",
+		"real":             "float",
+		"float":            "double",
+		"double":           "double",
+		"double precision": "double",
+		"numeric":          `{"type": "bytes", "logicalType": "decimal"}`,
+		"decimal":          `{"type": "bytes", "logicalType": "decimal"}`,
+		"bytea":            "bytes",
+		"blob":             "bytes",
+		"uuid":             `{"type": "string", "logicalType": "uuid"}`,
+		"date":             `{"type": "int", "logicalType": "date"}`,
+		"time":             `{"type": "long", "logicalType": "time-micros"}`,
+		"datetime":         `{"type": "long", "logicalType": "local-timestamp-micros"}`,
+		"timestamp":        `{"type": "long", "logicalType": "timestamp-micros"}`,
+		"timestamptz":      `{"type": "long", "logicalType": "timestamp-micros"}`,
+	},
+	Default: "string",
+}
+
+type avroRecord struct {
+	Type   string      `json:"type"`
+	Name   string      `json:"name"`
+	Doc    string      `json:"doc,omitempty"`
+	Fields []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Doc     string          `json:"doc,omitempty"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// avroType returns the Avro schema of a column of a type of the type map.
+func avroType(types TypeMap, c Column) (interface{}, error) {
+	element, array := elementColumn(c)
+	schema, err := schemaType(types.TypeOf(element))
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := schema.(map[string]interface{}); ok && m["logicalType"] == "decimal" {
+		precision, scale := 38, 9
+		if args := typeArguments(element); len(args) > 0 {
+			if precision, err = strconv.Atoi(args[0]); err != nil {
+				return nil, fmt.Errorf("invalid precision of %s", element.Type)
+			}
+			scale = 0
+			if len(args) > 1 {
+				if scale, err = strconv.Atoi(args[1]); err != nil {
+					return nil, fmt.Errorf("invalid scale of %s", element.Type)
+				}
+			}
+		}
+		if _, ok := m["precision"]; !ok {
+			m["precision"] = precision
+		}
+		if _, ok := m["scale"]; !ok {
+			m["scale"] = scale
+		}
+	}
+	if array {
+		schema = map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema, nil
+}
+
+// ExportAvro writes an Avro schema with a record per table, as a union of
+// the records. Nullable columns are unions with null which default to null,
+// and descriptions become docs of the records and fields.
+func ExportAvro(p ParsedData, types TypeMap, wr io.Writer) error {
+	records := []avroRecord{}
+	names := uniqueNames{}
+	for _, t := range p.Tables() {
+		record := avroRecord{Type: "record", Name: names.add(tsName(t.Name)), Doc: t.Description, Fields: []avroField{}}
+		fields := uniqueNames{}
+		for _, c := range t.Columns {
+			typ, err := avroType(types, c)
+			if err != nil {
+				return fmt.Errorf("line %d: %s.%s: %v", c.Line, t.Name, c.Name, err)
+			}
+			field := avroField{Name: fields.add(schemaFieldName(c.Name)), Type: typ, Doc: c.Description}
+			if c.Nullable() {
+				field.Type = []interface{}{"null", typ}
+				field.Default = json.RawMessage("null")
+			}
+			record.Fields = append(record.Fields, field)
+		}
+		records = append(records, record)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := wr.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
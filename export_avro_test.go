@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportAvro(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportAvro(parseSample(t), AvroTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.avsc", buf.Bytes()))
+	})
+
+	Convey("Logical types, decimals, arrays and nullable columns", t, func() {
+		err, parser := parse(t, `
+events : Events {
+  id
+  tags TEXT[]
+  at timestamp NULL : when it happened
+  amount decimal(10, 2)
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(ExportAvro(parser, AvroTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `[
+  {
+    "type": "record",
+    "name": "Events",
+    "doc": "Events",
+    "fields": [
+      {
+        "name": "id",
+        "type": "long"
+      },
+      {
+        "name": "tags",
+        "type": {
+          "items": "string",
+          "type": "array"
+        }
+      },
+      {
+        "name": "at",
+        "type": [
+          "null",
+          {
+            "logicalType": "timestamp-micros",
+            "type": "long"
+          }
+        ],
+        "doc": "when it happened",
+        "default": null
+      },
+      {
+        "name": "amount",
+        "type": {
+          "logicalType": "decimal",
+          "precision": 10,
+          "scale": 2,
+          "type": "bytes"
+        }
+      }
+    ]
+  }
+]
+`)
+	})
+
+	Convey("Invalid JSON types are errors", t, func() {
+		err, parser := parse(t, `
+events {
+  at timestamp
+}`)
+		So(err, ShouldBeNil)
+		types := TypeMap{Types: map[string]string{"timestamp": "{long"}, Default: "string"}
+		So(ExportAvro(parser, types, &bytes.Buffer{}), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// BigQueryTypes is the default mapping of column types to BigQuery types.
+var BigQueryTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "INT64",
+		"int":              "INT64",
+		"serial":           "INT64",
+		"bigint":           "INT64",
+		"bigserial":        "INT64",
+		"boolean":          "BOOL",
+		"real":             "FLOAT64",
+		"float":            "FLOAT64",
+		"double":           "FLOAT64",
+		"double precision": "FLOAT64",
+		"numeric":          "NUMERIC",
+		"decimal":          "NUMERIC",
+		"bytea":            "BYTES",
+		"blob":             "BYTES",
+		"date":             "DATE",
+		"time":             "TIME",
+		"datetime":         "DATETIME",
+		"timestamp":        "TIMESTAMP",
+		"timestamptz":      "TIMESTAMP",
+		"json":             "JSON",
+		"jsonb":            "JSON",
+	},
+	Default: "STRING",
+}
+
+var schemaIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// schemaFieldName returns the name of the field of a column in BigQuery and
+// Avro schemas, which only allow letters, digits and underscores.
+func schemaFieldName(column string) string {
+	if schemaIdentifier.MatchString(column) {
+		return column
+	}
+	return protoField(column)
+}
+
+// elementColumn returns the column with the type of the elements of its
+// array type, like TEXT of TEXT[], and whether it is an array.
+func elementColumn(c Column) (Column, bool) {
+	t := strings.TrimSpace(c.BaseType())
+	if !strings.HasSuffix(t, "[]") {
+		return c, false
+	}
+	c.Type = strings.TrimSpace(strings.TrimSuffix(t, "[]"))
+	return c, true
+}
+
+type bigqueryField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Mode        string `json:"mode"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExportBigQuery writes the BigQuery schemas of the tables, as a JSON object
+// mapping each table name to the list of fields bq takes as a schema file.
+// Nullable columns are NULLABLE, arrays are REPEATED, and the others are
+// REQUIRED. Descriptions become descriptions of the fields. A table defined
+// twice keeps its first definition.
+func ExportBigQuery(p ParsedData, types TypeMap, wr io.Writer) error {
+	schemas := map[string][]bigqueryField{}
+	for _, t := range p.Tables() {
+		if _, ok := schemas[t.Name]; ok {
+			continue
+		}
+		fields := []bigqueryField{}
+		names := uniqueNames{}
+		for _, c := range t.Columns {
+			mode := "REQUIRED"
+			if c.Nullable() {
+				mode = "NULLABLE"
+			}
+			element, repeated := elementColumn(c)
+			if repeated {
+				mode = "REPEATED"
+			}
+			fields = append(fields, bigqueryField{
+				Name:        names.add(schemaFieldName(c.Name)),
+				Type:        types.TypeOf(element),
+				Mode:        mode,
+				Description: c.Description,
+			})
+		}
+		schemas[t.Name] = fields
+	}
+
+	data, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := wr.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportBigQuery(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportBigQuery(parseSample(t), BigQueryTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.bigquery.json", buf.Bytes()))
+	})
+
+	Convey("Modes follow nullability and arrays, with a custom type map", t, func() {
+		err, parser := parse(t, `
+events {
+  id
+  tags TEXT[]
+  payload jsonb NULL : raw event
+  amount decimal(10, 2)
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		types := TypeMap{Types: map[string]string{"bigint": "INT64", "jsonb": "STRING"}, Default: "STRING"}
+		var buf bytes.Buffer
+		So(ExportBigQuery(parser, types, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `{
+  "events": [
+    {
+      "name": "id",
+      "type": "INT64",
+      "mode": "REQUIRED"
+    },
+    {
+      "name": "tags",
+      "type": "STRING",
+      "mode": "REPEATED"
+    },
+    {
+      "name": "payload",
+      "type": "STRING",
+      "mode": "NULLABLE",
+      "description": "raw event"
+    },
+    {
+      "name": "amount",
+      "type": "STRING",
+      "mode": "REQUIRED"
+    }
+  ]
+}
+`)
+	})
+
+	Convey("A table defined twice keeps its first definition", t, func() {
+		err, parser := parse(t, `
+a {
+  id
+}
+
+a {
+  name
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportBigQuery(parser, BigQueryTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `"name": "id"`)
+		So(buf.String(), ShouldNotContainSubstring, `"name": "name"`)
+	})
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// dbmlUntyped is the DBML type of columns without a type, which DBML
+// requires. ParseDBML reads it back as no type.
+const dbmlUntyped = "any"
+
+var dbmlPlainName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// dbmlName quotes a table or column name unless it is a plain identifier.
+func dbmlName(name string) string {
+	if dbmlPlainName.MatchString(name) {
+		return name
+	}
+	return dbmlQuote(name, '"')
+}
+
+// dbmlQuote quotes s, escaping the quote, backslashes and newlines.
+func dbmlQuote(s string, quote rune) string {
+	q := string(quote)
+	r := strings.NewReplacer(`\`, `\\`, q, `\`+q, "\n", `\n`)
+	return q + r.Replace(s) + q
+}
+
+// dbmlPlainType matches types which need no quotes, like varchar(255),
+// decimal(10, 2) or int[].
+var dbmlPlainType = regexp.MustCompile(`^[A-Za-z0-9_.]+(\([^()\[\]{}"'\n]*\))?(\[\])*$`)
+
+func dbmlType(t string) string {
+	switch {
+	case t == "":
+		return dbmlUntyped
+	case !dbmlPlainType.MatchString(t):
+		return dbmlQuote(t, '"')
+	}
+	return t
+}
+
+func dbmlString(s string) string {
+	return dbmlQuote(s, '\'')
+}
+
+// dbmlSettings returns the settings in brackets after a column definition.
+// Relations are many-to-one, or one-to-one from primary keys, as in
+// crowsFoot.
+func dbmlSettings(t Table, c Column) string {
+	var settings []string
+	if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+		settings = append(settings, "pk")
+	}
+	if r := c.Relation; r != nil {
+		op := ">"
+		if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+			op = "-"
+		}
+		settings = append(settings, fmt.Sprintf("ref: %s %s.%s", op, dbmlName(r.TableName), dbmlName(r.ColumnName)))
+	}
+	if c.Description != "" {
+		settings = append(settings, "note: "+dbmlString(c.Description))
+	}
+	if len(settings) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(settings, ", ") + "]"
+}
+
+// ExportDBML writes the tables in the DBML of dbdiagram.io. Relations are
+// written as settings of the columns; DBML has no dotted lines, so every
+// relation becomes a solid one.
+func ExportDBML(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	for i, t := range p.Tables() {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "Table %s {\n", dbmlName(t.Name))
+		if t.Description != "" {
+			fmt.Fprintf(&buf, "  Note: %s\n", dbmlString(t.Description))
+		}
+
+		var rows [][]string
+		nameWidth, typeWidth := 0, 0
+		for _, c := range t.Columns {
+			row := []string{dbmlName(c.Name), dbmlType(c.Type), dbmlSettings(t, c)}
+			if w := textCells(row[0]); w > nameWidth {
+				nameWidth = w
+			}
+			if w := textCells(row[1]); w > typeWidth && row[2] != "" {
+				typeWidth = w
+			}
+			rows = append(rows, row)
+		}
+		for _, row := range rows {
+			line := asciiPad(row[0], nameWidth) + " " + row[1]
+			if row[2] != "" {
+				line = asciiPad(line, nameWidth+1+typeWidth) + " " + row[2]
+			}
+			buf.WriteString("  " + line + "\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
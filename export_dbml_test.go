@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportDBML(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportDBML(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.dbml", buf.Bytes()))
+	})
+
+	Convey("Names, types and strings are quoted when needed", t, func() {
+		So(dbmlName("users"), ShouldEqual, "users")
+		So(dbmlName("user accounts"), ShouldEqual, `"user accounts"`)
+		So(dbmlType(""), ShouldEqual, "any")
+		So(dbmlType("varchar(255)"), ShouldEqual, "varchar(255)")
+		So(dbmlType("double precision"), ShouldEqual, `"double precision"`)
+		So(dbmlString(`it's a \ path`), ShouldEqual, `'it\'s a \\ path'`)
+	})
+
+	Convey("ParseDBML reads back what ExportDBML writes", t, func() {
+		p := parseSample(t)
+		var buf bytes.Buffer
+		So(ExportDBML(p, &buf), ShouldBeNil)
+		schema, err := ParseDBML(buf.String())
+		So(err, ShouldBeNil)
+
+		// DBML has no dotted lines
+		want := withoutLines(p.Tables())
+		for _, table := range want {
+			for i, c := range table.Columns {
+				if c.Relation != nil {
+					r := *c.Relation
+					r.LineType = NormalLine
+					table.Columns[i].Relation = &r
+				}
+			}
+		}
+		So(withoutLines(schema), ShouldResemble, want)
+	})
+}
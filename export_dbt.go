@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// dbtTests returns the generic tests of a column: not_null unless it is
+// nullable, unique from its type constraints, both for the primary key, and
+// relationships from its relation.
+func dbtTests(t Table, c Column) []string {
+	_, unique, primaryKey := typeConstraints(c.Type)
+	notNull := !c.Nullable()
+	if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name || primaryKey {
+		notNull, unique = true, true
+	}
+
+	var tests []string
+	if notNull {
+		tests = append(tests, "- not_null")
+	}
+	if unique {
+		tests = append(tests, "- unique")
+	}
+	if r := c.Relation; r != nil {
+		tests = append(tests,
+			"- relationships:",
+			fmt.Sprintf("    to: %s", yamlString(fmt.Sprintf("ref('%s')", r.TableName))),
+			fmt.Sprintf("    field: %s", yamlString(r.ColumnName)))
+	}
+	return tests
+}
+
+// ExportDBT writes a dbt schema.yml with a model per table and its columns,
+// described as in the erd file. Columns which are not nullable get not_null
+// tests, the primary key and columns with UNIQUE or PRIMARY KEY in their
+// types get unique tests, and relations become relationships tests.
+func ExportDBT(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("version: 2\n\nmodels:\n")
+	for i, t := range p.Tables() {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "  - name: %s\n", yamlString(t.Name))
+		if t.Description != "" {
+			fmt.Fprintf(&buf, "    description: %s\n", strconv.Quote(t.Description))
+		}
+		if len(t.Columns) == 0 {
+			continue
+		}
+		buf.WriteString("    columns:\n")
+		for _, c := range t.Columns {
+			fmt.Fprintf(&buf, "      - name: %s\n", yamlString(c.Name))
+			if c.Description != "" {
+				fmt.Fprintf(&buf, "        description: %s\n", strconv.Quote(c.Description))
+			}
+			if tests := dbtTests(t, c); len(tests) > 0 {
+				buf.WriteString("        tests:\n")
+				for _, test := range tests {
+					buf.WriteString("          " + test + "\n")
+				}
+			}
+		}
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportDBT(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportDBT(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.dbt.yml", buf.Bytes()))
+	})
+
+	Convey("Constraints and relations become tests", t, func() {
+		err, parser := parse(t, `
+orders : Orders "placed" {
+  code TEXT PRIMARY KEY
+  email TEXT UNIQUE NOT NULL : contact
+  note TEXT NULL
+  user_id BIGINT NOT NULL -> users.id
+  total BIGINT NOT NULL UNIQUE
+  memo TEXT
+}
+
+users {
+  yes
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportDBT(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `version: 2
+
+models:
+  - name: orders
+    description: "Orders \"placed\""
+    columns:
+      - name: code
+        tests:
+          - not_null
+          - unique
+      - name: email
+        description: "contact"
+        tests:
+          - not_null
+          - unique
+      - name: note
+      - name: user_id
+        tests:
+          - not_null
+          - relationships:
+              to: "ref('users')"
+              field: id
+      - name: total
+        tests:
+          - not_null
+          - unique
+      - name: memo
+        tests:
+          - not_null
+
+  - name: users
+    columns:
+      - name: "yes"
+        tests:
+          - not_null
+`)
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// unquotedHash tells whether s has a "#" outside double quotes, which ends a
+// description and starts a comment.
+func unquotedHash(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '#':
+			return true
+		case '"':
+			if j := strings.IndexAny(s[i+1:], "\"\n"); j >= 0 && s[i+1+j] == '"' {
+				i += j + 1
+			}
+		}
+	}
+	return false
+}
+
+// validateERD checks that every field of the tables can be written in the
+// erd language and read back unchanged.
+func validateERD(tables []Table) error {
+	text := func(kind, s, forbidden string) error {
+		if !utf8.ValidString(s) {
+			return fmt.Errorf("%s %q is not valid UTF-8", kind, s)
+		}
+		if s != strings.TrimSpace(s) {
+			return fmt.Errorf("%s %q has surrounding spaces", kind, s)
+		}
+		if strings.ContainsAny(s, forbidden) {
+			return fmt.Errorf("%s %q contains one of %q", kind, s, forbidden)
+		}
+		return nil
+	}
+	description := func(kind, s, forbidden string) error {
+		if err := text(kind, s, forbidden); err != nil {
+			return err
+		}
+		if unquotedHash(s) {
+			return fmt.Errorf("%s %q has a # outside quotes", kind, s)
+		}
+		return nil
+	}
+
+	for _, t := range tables {
+		if !identifierPattern.MatchString(t.Name) {
+			return fmt.Errorf("invalid table name %q", t.Name)
+		}
+		if err := description("description of table "+t.Name, t.Description, "\n{"); err != nil {
+			return err
+		}
+		if len(t.Columns) == 0 {
+			return fmt.Errorf("table %s has no columns", t.Name)
+		}
+		for _, c := range t.Columns {
+			if !identifierPattern.MatchString(c.Name) {
+				return fmt.Errorf("invalid column name %q in table %s", c.Name, t.Name)
+			}
+			if err := text("type of column "+t.Name+"."+c.Name, c.Type, "-:.\n#"); err != nil {
+				return err
+			}
+			if err := description("description of column "+t.Name+"."+c.Name, c.Description, "\n"); err != nil {
+				return err
+			}
+			if r := c.Relation; r != nil {
+				if !identifierPattern.MatchString(r.TableName) || !identifierPattern.MatchString(r.ColumnName) {
+					return fmt.Errorf("invalid relation %s.%s of column %s.%s", r.TableName, r.ColumnName, t.Name, c.Name)
+				}
+				if r.LineType != NormalLine && r.LineType != DotLine {
+					return fmt.Errorf("invalid line type %d of column %s.%s", r.LineType, t.Name, c.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ExportERD writes the tables in the erd language itself, laid out the way
+// erd fmt does, so that parsing the output gives back the same tables.
+func ExportERD(p ParsedData, wr io.Writer) error {
+	tables := p.Tables()
+	if err := validateERD(tables); err != nil {
+		return err
+	}
+
+	for i, t := range tables {
+		if i > 0 {
+			if _, err := io.WriteString(wr, "\n"); err != nil {
+				return err
+			}
+		}
+		lines := append([]string{tableHeader(t)}, columnLines(t.Columns, make([]string, len(t.Columns)))...)
+		lines = append(lines, "}")
+		if _, err := io.WriteString(wr, strings.Join(lines, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// randomSchema generates arbitrary tables that the erd language can express.
+type randomSchema Schema
+
+func randomString(r *rand.Rand, alphabet []rune, min, max int) string {
+	n := min + r.Intn(max-min+1)
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(runes)
+}
+
+var (
+	identifierRunes  = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_")
+	typeRunes        = []rune("abcXYZ019_ (),>'\"{}\tあ")
+	descriptionRunes = []rune("abcXYZ019_ -:.>#'\"(){}\t/あ漢")
+)
+
+func randomText(r *rand.Rand, alphabet []rune, forbidden string) string {
+	if r.Intn(3) == 0 {
+		return ""
+	}
+	s := randomString(r, alphabet, 1, 20)
+	s = strings.Map(func(c rune) rune {
+		if strings.ContainsRune(forbidden, c) {
+			return 'x'
+		}
+		return c
+	}, s)
+	if unquotedHash(s) {
+		s = strings.Replace(s, "#", "x", -1)
+	}
+	if s = strings.TrimSpace(s); s == "" {
+		return "x"
+	}
+	return s
+}
+
+func (randomSchema) Generate(r *rand.Rand, size int) reflect.Value {
+	var tables randomSchema
+	for i := r.Intn(size/10 + 2); i > 0; i-- {
+		t := Table{
+			Name:        randomString(r, identifierRunes, 1, 10),
+			Description: randomText(r, descriptionRunes, "{"),
+			Columns:     make([]Column, 0),
+		}
+		for j := 1 + r.Intn(size/5+1); j > 0; j-- {
+			c := Column{
+				Name:        randomString(r, identifierRunes, 1, 10),
+				Type:        randomText(r, typeRunes, ""),
+				Description: randomText(r, descriptionRunes, ""),
+			}
+			if r.Intn(2) == 0 {
+				c.Relation = &Relation{
+					LineType:   []LineType{NormalLine, DotLine}[r.Intn(2)],
+					TableName:  randomString(r, identifierRunes, 1, 10),
+					ColumnName: randomString(r, identifierRunes, 1, 10),
+				}
+			}
+			t.Columns = append(t.Columns, c)
+		}
+		tables = append(tables, t)
+	}
+	return reflect.ValueOf(tables)
+}
+
+// withoutLines clears the source positions, which depend on the layout.
+func withoutLines(tables []Table) []Table {
+	var ret []Table
+	for _, t := range tables {
+		t.Line = 0
+		columns := make([]Column, 0)
+		for _, c := range t.Columns {
+			c.Line = 0
+			columns = append(columns, c)
+		}
+		t.Columns = columns
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+func TestExportERD(t *testing.T) {
+	Convey("sample.erd round-trips", t, func() {
+		source, err := ioutil.ReadFile("sample.erd")
+		So(err, ShouldBeNil)
+		err, parser := parse(t, string(source))
+		So(err, ShouldBeNil)
+
+		var buf bytes.Buffer
+		So(ExportERD(parser, &buf), ShouldBeNil)
+		formatted, err := FormatSource(string(source))
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, string(formatted))
+	})
+
+	Convey("Any expressible schema round-trips", t, func() {
+		roundTrip := func(schema randomSchema) bool {
+			var buf bytes.Buffer
+			if err := ExportERD(Schema(schema), &buf); err != nil {
+				t.Log(err)
+				return false
+			}
+			parser, err := ParseText(buf.String())
+			if err != nil {
+				t.Log(buf.String(), err)
+				return false
+			}
+			return reflect.DeepEqual(withoutLines(parser.Tables()), withoutLines(schema))
+		}
+		So(quick.Check(roundTrip, &quick.Config{MaxCount: 500}), ShouldBeNil)
+	})
+
+	Convey("Inexpressible schemas are rejected", t, func() {
+		column := []Column{{Name: "id"}}
+		for _, tables := range []Schema{
+			{{Name: "has space", Columns: column}},
+			{{Name: "empty", Columns: []Column{}}},
+			{{Name: "t", Description: "a {", Columns: column}},
+			{{Name: "t", Description: " padded", Columns: column}},
+			{{Name: "t", Description: "issue #1", Columns: column}},
+			{{Name: "t", Columns: []Column{{Name: "id", Description: `"a" # b`}}}},
+			{{Name: "t", Columns: []Column{{Name: "id", Type: "NUMERIC(10.2)"}}}},
+			{{Name: "t", Columns: []Column{{Name: "id", Description: "two\nlines"}}}},
+			{{Name: "t", Columns: []Column{{Name: "id", Relation: &Relation{TableName: "u", ColumnName: "id"}}}}},
+		} {
+			So(ExportERD(tables, ioutil.Discard), ShouldNotBeNil)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GraphQLTypes is the mapping of column types to GraphQL scalars. Types
+// other than the built-in scalars are declared as custom scalars.
+var GraphQLTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "Int",
+		"int":              "Int",
+		"serial":           "Int",
+		"bigint":           "BigInt",
+		"bigserial":        "BigInt",
+		"boolean":          "Boolean",
+		"real":             "Float",
+		"float":            "Float",
+		"double":           "Float",
+		"double precision": "Float",
+		"numeric":          "Float",
+		"decimal":          "Float",
+		"uuid":             "ID",
+		"date":             "DateTime",
+		"datetime":         "DateTime",
+		"timestamp":        "DateTime",
+		"timestamptz":      "DateTime",
+		"json":             "JSON",
+		"jsonb":            "JSON",
+	},
+	Default: "String",
+}
+
+var graphqlBuiltins = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+var graphqlIdentifier = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// graphqlFieldName returns the name of the field of a column.
+func graphqlFieldName(column string) string {
+	if graphqlIdentifier.MatchString(column) && !strings.HasPrefix(column, "__") {
+		return column
+	}
+	return strings.TrimLeft(protoField(column), "_")
+}
+
+// graphqlDescription returns a description as a block string indented by
+// indent.
+func graphqlDescription(indent, description string) string {
+	description = strings.Replace(strings.TrimSpace(description), `"""`, `\"""`, -1)
+	if strings.HasSuffix(description, `"`) {
+		description += " "
+	}
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		return indent + `"""` + description + `"""` + "\n"
+	}
+	var buf bytes.Buffer
+	buf.WriteString(indent + `"""` + "\n")
+	for _, line := range lines {
+		buf.WriteString(strings.TrimRight(indent+strings.TrimSpace(line), " ") + "\n")
+	}
+	buf.WriteString(indent + `"""` + "\n")
+	return buf.String()
+}
+
+// ExportGraphQL writes a GraphQL object type per table, with a field per
+// column. Primary keys and the columns referencing them are IDs, and columns
+// which are not nullable are non-null. A relation adds a field of the type
+// it points to, and that type gets the field back, a list or, when the
+// column is the primary key, a nullable object. Descriptions become
+// descriptions of the types and fields.
+func ExportGraphQL(p ParsedData, wr io.Writer) error {
+	order := newRelationTypes(p.Tables(), tsName, graphqlFieldName)
+	types := map[string]*relationType{}
+	for _, g := range order {
+		types[g.Table.Name] = g
+	}
+	relations := relationFieldNames(order)
+
+	var body bytes.Buffer
+	scalars := map[string]bool{}
+	for _, g := range order {
+		body.WriteString("\n")
+		if g.Table.Description != "" {
+			body.WriteString(graphqlDescription("", g.Table.Description))
+		}
+		fmt.Fprintf(&body, "type %s {\n", g.Name)
+		for _, c := range g.Table.Columns {
+			typ := GraphQLTypes.TypeOf(c)
+			if pk := g.Table.PrimaryKey(); pk != nil && pk.Name == c.Name {
+				typ = "ID"
+			} else if r := c.Relation; r != nil {
+				if target, ok := types[r.TableName]; ok {
+					if pk := target.Table.PrimaryKey(); pk != nil && pk.Name == r.ColumnName {
+						typ = "ID"
+					}
+				}
+			}
+			if !graphqlBuiltins[typ] {
+				scalars[typ] = true
+			}
+			if !c.Nullable() {
+				typ += "!"
+			}
+			if c.Description != "" {
+				body.WriteString(graphqlDescription("  ", c.Description))
+			}
+			fmt.Fprintf(&body, "  %s: %s\n", g.Fields[c.Name], typ)
+		}
+		for _, r := range relations {
+			if r.Table.Name == g.Table.Name {
+				typ := types[r.Target.Name].Name
+				if !r.Column.Nullable() {
+					typ += "!"
+				}
+				fmt.Fprintf(&body, "  %s: %s\n", r.Field, typ)
+			}
+		}
+		for _, r := range relations {
+			if r.Target.Name == g.Table.Name {
+				typ := fmt.Sprintf("[%s!]!", types[r.Table.Name].Name)
+				if pk := r.Table.PrimaryKey(); pk != nil && pk.Name == r.Column.Name {
+					typ = types[r.Table.Name].Name
+				}
+				fmt.Fprintf(&body, "  %s: %s\n", r.BackField, typ)
+			}
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	if len(scalars) > 0 {
+		var names []string
+		for name := range scalars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&buf, "scalar %s\n", name)
+		}
+		body.WriteTo(&buf)
+	} else {
+		// without scalars, the blank line before the first type
+		buf.Write(bytes.TrimPrefix(body.Bytes(), []byte("\n")))
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
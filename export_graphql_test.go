@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportGraphQL(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportGraphQL(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.graphql", buf.Bytes()))
+	})
+
+	Convey("Relations get fields in both directions", t, func() {
+		err, parser := parse(t, `
+messages {
+  id
+  sender_id BIGINT NULL -> users.id
+  recipient_id -> users.id
+  code -> users.code
+  sent_at timestamp
+}
+
+users : "People" {
+  id
+  code TEXT : unique
+}
+
+profiles {
+  id -> users.id
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(ExportGraphQL(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `scalar DateTime
+
+type Messages {
+  id: ID!
+  sender_id: ID
+  recipient_id: ID!
+  code: String!
+  sent_at: DateTime!
+  sender: Users
+  recipient: Users!
+  code_ref: Users!
+}
+
+""""People" """
+type Users {
+  id: ID!
+  """unique"""
+  code: String!
+  messages_sender: [Messages!]!
+  messages_recipient: [Messages!]!
+  messages_code_ref: [Messages!]!
+  profiles: Profiles
+}
+
+type Profiles {
+  id: ID!
+  id_ref: Users!
+}
+`)
+	})
+
+	Convey("Multi-line descriptions are block strings", t, func() {
+		So(graphqlDescription("  ", "first\n second"), ShouldEqual, "  \"\"\"\n  first\n  second\n  \"\"\"\n")
+		So(graphqlFieldName("order-no"), ShouldEqual, "order_no")
+		So(graphqlFieldName("__typename"), ShouldEqual, "typename")
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONVersion is the version of the JSON written by ExportJSON by default.
+// Version 0 is the old output, the tables marshaled as they were before
+// Table and Column had more fields.
+// schema/erd.v1.schema.json describes version 1.
+const JSONVersion = 1
+
+type jsonDocument struct {
+	Version int         `json:"version"`
+	Tables  []jsonTable `json:"tables"`
+}
+
+type jsonTable struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Line        int          `json:"line,omitempty"`
+	Columns     []jsonColumn `json:"columns"`
+}
+
+type jsonColumn struct {
+	Name        string        `json:"name"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	Line        int           `json:"line,omitempty"`
+	Relation    *jsonRelation `json:"relation,omitempty"`
+}
+
+type jsonRelation struct {
+	Table    string `json:"table"`
+	Column   string `json:"column"`
+	LineType string `json:"line_type"`
+}
+
+// jsonV0Table, jsonV0Column and jsonV0Relation freeze the fields of the
+// version 0 output, named as the fields of the structs were.
+type jsonV0Table struct {
+	Name        string
+	Description string
+	Columns     []jsonV0Column
+}
+
+type jsonV0Column struct {
+	Name        string
+	Relation    *jsonV0Relation
+	Description string
+	Type        string
+}
+
+type jsonV0Relation struct {
+	LineType   LineType
+	TableName  string
+	ColumnName string
+}
+
+func newJSONV0Tables(tables []Table) []jsonV0Table {
+	var v0 []jsonV0Table
+	for _, t := range tables {
+		table := jsonV0Table{Name: t.Name, Description: t.Description, Columns: []jsonV0Column{}}
+		for _, c := range t.Columns {
+			column := jsonV0Column{Name: c.Name, Description: c.Description, Type: c.Type}
+			if r := c.Relation; r != nil {
+				column.Relation = &jsonV0Relation{LineType: r.LineType, TableName: r.TableName, ColumnName: r.ColumnName}
+			}
+			table.Columns = append(table.Columns, column)
+		}
+		v0 = append(v0, table)
+	}
+	return v0
+}
+
+func newJSONDocument(tables []Table) jsonDocument {
+	doc := jsonDocument{Version: 1, Tables: []jsonTable{}}
+	for _, t := range tables {
+		table := jsonTable{
+			Name:        t.Name,
+			Description: t.Description,
+			Line:        t.Line,
+			Columns:     []jsonColumn{},
+		}
+		for _, c := range t.Columns {
+			column := jsonColumn{
+				Name:        c.Name,
+				Type:        c.Type,
+				Description: c.Description,
+				Line:        c.Line,
+			}
+			if r := c.Relation; r != nil {
+				column.Relation = &jsonRelation{
+					Table:    r.TableName,
+					Column:   r.ColumnName,
+					LineType: r.LineStyleLiteral(),
+				}
+			}
+			table.Columns = append(table.Columns, column)
+		}
+		doc.Tables = append(doc.Tables, table)
+	}
+	return doc
+}
+
+// ExportJSON writes the tables as JSON of the given version, see JSONVersion.
+func ExportJSON(p ParsedData, version int, wr io.Writer) error {
+	var data []byte
+	var err error
+	switch version {
+	case 0:
+		data, err = json.Marshal(newJSONV0Tables(p.Tables()))
+	case 1:
+		data, err = json.MarshalIndent(newJSONDocument(p.Tables()), "", "  ")
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown JSON version %d (available: 0, 1)", version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := wr.Write(data); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// jsonKeys returns the keys the JSON of v has, and the ones it always has.
+func jsonKeys(v interface{}) (keys, required []string) {
+	typ := reflect.TypeOf(v)
+	for i := 0; i < typ.NumField(); i++ {
+		tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")
+		keys = append(keys, tag[0])
+		if len(tag) == 1 {
+			required = append(required, tag[0])
+		}
+	}
+	sort.Strings(keys)
+	sort.Strings(required)
+	return keys, required
+}
+
+func TestExportJSON(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportJSON(parseSample(t), JSONVersion, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.json", buf.Bytes()))
+	})
+
+	Convey("Version 1 uses snake_case keys and named line types", t, func() {
+		err, parser := parse(t, `
+users {
+  id
+}
+posts {
+  user_id -> users.id
+  blog_id ..> blogs.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportJSON(parser, 1, &buf), ShouldBeNil)
+
+		var doc map[string]interface{}
+		So(json.Unmarshal(buf.Bytes(), &doc), ShouldBeNil)
+		So(doc["version"], ShouldEqual, 1)
+		tables := doc["tables"].([]interface{})
+		So(len(tables), ShouldEqual, 2)
+		columns := tables[1].(map[string]interface{})["columns"].([]interface{})
+		So(columns[0].(map[string]interface{})["relation"], ShouldResemble, map[string]interface{}{
+			"table": "users", "column": "id", "line_type": "solid",
+		})
+		So(columns[1].(map[string]interface{})["relation"].(map[string]interface{})["line_type"], ShouldEqual, "dotted")
+	})
+
+	Convey("Empty lists are arrays, not null", t, func() {
+		var buf bytes.Buffer
+		So(ExportJSON(Schema{}, 1, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, "{\n  \"version\": 1,\n  \"tables\": []\n}\n")
+		buf.Reset()
+		So(ExportJSON(Schema{{Name: "empty"}}, 1, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `"columns": []`)
+	})
+
+	Convey("Version 0 is the unversioned output", t, func() {
+		var buf bytes.Buffer
+		p := parseSample(t)
+		So(ExportJSON(p, 0, &buf), ShouldBeNil)
+		// testdata/sample.v0.json is the output of erd before versioning,
+		// which -update must not rewrite
+		want, err := ioutil.ReadFile("testdata/sample.v0.json")
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, string(want))
+
+		buf.Reset()
+		So(ExportJSON(Schema{}, 0, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, "null")
+	})
+
+	Convey("Unknown versions are errors", t, func() {
+		var buf bytes.Buffer
+		So(ExportJSON(parseSample(t), 2, &buf), ShouldNotBeNil)
+	})
+
+	Convey("The published schema has the keys of the output", t, func() {
+		data, err := ioutil.ReadFile("schema/erd.v1.schema.json")
+		So(err, ShouldBeNil)
+		var schema struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+			Defs       map[string]struct {
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"$defs"`
+		}
+		So(json.Unmarshal(data, &schema), ShouldBeNil)
+
+		check := func(v interface{}, required []string, properties map[string]json.RawMessage) {
+			keys, wantRequired := jsonKeys(v)
+			var names []string
+			for name := range properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			sort.Strings(required)
+			So(names, ShouldResemble, keys)
+			So(required, ShouldResemble, wantRequired)
+		}
+		check(jsonDocument{}, schema.Required, schema.Properties)
+		check(jsonTable{}, schema.Defs["table"].Required, schema.Defs["table"].Properties)
+		check(jsonColumn{}, schema.Defs["column"].Required, schema.Defs["column"].Properties)
+		check(jsonRelation{}, schema.Defs["relation"].Required, schema.Defs["relation"].Properties)
+	})
+}
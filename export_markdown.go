@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// markdownAnchor returns the anchor GitHub generates for a heading.
+func markdownAnchor(heading string) string {
+	var anchor []rune
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			anchor = append(anchor, '-')
+		case r == '-', r == '_', unicode.IsLetter(r), unicode.IsDigit(r):
+			anchor = append(anchor, r)
+		}
+	}
+	return "#" + string(anchor)
+}
+
+// markdownAnchors returns the anchors of the headings of the tables, which
+// follow the "Tables" heading. GitHub numbers the anchors of repeated
+// headings, so a table named tables gets #tables-1.
+func markdownAnchors(tables []Table) []string {
+	seen := map[string]int{markdownAnchor("Tables"): 1}
+	anchors := make([]string, len(tables))
+	for i, t := range tables {
+		anchor := markdownAnchor(t.Name)
+		anchors[i] = anchor
+		if n := seen[anchor]; n > 0 {
+			anchors[i] = fmt.Sprintf("%s-%d", anchor, n)
+		}
+		seen[anchor]++
+	}
+	return anchors
+}
+
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+// markdownColumnLink links a column to the section of its table, which is
+// the first one of the tables of that name.
+func markdownColumnLink(anchors map[string]string, table, column string) string {
+	anchor, ok := anchors[table]
+	if !ok {
+		anchor = markdownAnchor(table)
+	}
+	return fmt.Sprintf("[%s.%s](%s)", table, column, anchor)
+}
+
+// ExportMarkdown writes a data dictionary with a section per table, listing
+// its columns, the columns they reference and the columns referencing it.
+func ExportMarkdown(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	anchors := markdownAnchors(p.Tables())
+	tableAnchors := map[string]string{}
+	buf.WriteString("# Tables\n\n")
+	for i, t := range p.Tables() {
+		fmt.Fprintf(&buf, "- [%s](%s)\n", t.Name, anchors[i])
+		if _, ok := tableAnchors[t.Name]; !ok {
+			tableAnchors[t.Name] = anchors[i]
+		}
+	}
+
+	for _, t := range p.Tables() {
+		fmt.Fprintf(&buf, "\n## %s\n\n", t.Name)
+		if t.Description != "" {
+			buf.WriteString(t.Description + "\n\n")
+		}
+
+		buf.WriteString("| Column | Type | Description | References |\n")
+		buf.WriteString("| --- | --- | --- | --- |\n")
+		for _, c := range t.Columns {
+			references := ""
+			if c.Relation != nil {
+				references = markdownColumnLink(tableAnchors, c.Relation.TableName, c.Relation.ColumnName)
+			}
+			fmt.Fprintf(&buf, "| %s | %s | %s | %s |\n",
+				markdownCell(c.Name), markdownCell(c.Type), markdownCell(c.Description), references)
+		}
+
+		if refs := ReferencesTo(p.Tables(), t.Name); len(refs) > 0 {
+			buf.WriteString("\nReferenced by:\n\n")
+			for _, ref := range refs {
+				fmt.Fprintf(&buf, "- %s\n", markdownColumnLink(tableAnchors, ref.Table.Name, ref.Column.Name))
+			}
+		}
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
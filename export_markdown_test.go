@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportMarkdown(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.md", buf.Bytes()))
+	})
+
+	Convey("Anchors and cells", t, func() {
+		So(markdownAnchor("User Accounts"), ShouldEqual, "#user-accounts")
+		So(markdownAnchor("blog_posts"), ShouldEqual, "#blog_posts")
+		So(markdownAnchor("Blog.Posts!"), ShouldEqual, "#blogposts")
+		So(markdownCell("a | b\nc"), ShouldEqual, `a \| b<br>c`)
+	})
+
+	Convey("Anchors of tables do not collide with the index or each other", t, func() {
+		So(markdownAnchors([]Table{{Name: "tables"}, {Name: "users"}, {Name: "Users"}, {Name: "tables"}}),
+			ShouldResemble, []string{"#tables-1", "#users", "#users-1", "#tables-2"})
+
+		err, parser := parse(t, `
+tables {
+  id
+}
+
+columns {
+  table_id -> tables.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportMarkdown(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "- [tables](#tables-1)\n")
+		So(buf.String(), ShouldContainSubstring, "[tables.id](#tables-1)")
+		So(buf.String(), ShouldContainSubstring, "[columns.table_id](#columns)")
+	})
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var mermaidTypeInvalid = regexp.MustCompile(`[^A-Za-z0-9_()\[\]-]+`)
+
+// mermaidType makes a column type usable as a Mermaid attribute type, which
+// must be a single word. Untyped columns are shown as "any".
+func mermaidType(t string) string {
+	t = strings.Trim(mermaidTypeInvalid.ReplaceAllString(t, "_"), "_")
+	if t == "" {
+		return "any"
+	}
+	return t
+}
+
+func mermaidKeys(t Table, c Column) string {
+	var keys []string
+	if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+		keys = append(keys, "PK")
+	}
+	if c.Relation != nil {
+		keys = append(keys, "FK")
+	}
+	return strings.Join(keys, ", ")
+}
+
+// crowsFoot returns the crow's foot notation, shared by Mermaid and PlantUML,
+// for a relation from a column of t. A column references one row of the
+// target table, which is referenced by many rows of t unless the column is
+// t's primary key. Solid lines are identifying relationships and dotted lines
+// non-identifying ones.
+func crowsFoot(t Table, c Column) string {
+	left := "}o"
+	if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+		left = "|o"
+	}
+	line := "--"
+	if c.Relation.LineType == DotLine {
+		line = ".."
+	}
+	return left + line + "||"
+}
+
+func mermaidString(s string) string {
+	return `"` + strings.Replace(s, `"`, "'", -1) + `"`
+}
+
+// ExportMermaid writes the tables as a Mermaid erDiagram, which GitHub and
+// GitLab render in Markdown.
+func ExportMermaid(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("erDiagram\n")
+	for _, t := range p.Tables() {
+		if t.Description != "" {
+			fmt.Fprintf(&buf, "    %%%% %s\n", t.Description)
+		}
+		fmt.Fprintf(&buf, "    %s {\n", t.Name)
+		for _, c := range t.Columns {
+			line := mermaidType(c.Type) + " " + c.Name
+			if keys := mermaidKeys(t, c); keys != "" {
+				line += " " + keys
+			}
+			if c.Description != "" {
+				line += " " + mermaidString(c.Description)
+			}
+			fmt.Fprintf(&buf, "        %s\n", line)
+		}
+		buf.WriteString("    }\n")
+	}
+	for _, t := range p.Tables() {
+		for _, c := range t.ColumnsWithRelation() {
+			fmt.Fprintf(&buf, "    %s %s %s : %s\n", t.Name, crowsFoot(t, c), c.Relation.TableName, c.Name)
+		}
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
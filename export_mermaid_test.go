@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportMermaid(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportMermaid(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.mermaid", buf.Bytes()))
+	})
+
+	Convey("Keys, cardinalities and types", t, func() {
+		err, parser := parse(t, `
+profiles {
+  id -> users.id
+  bio TEXT : Shown as "about me"
+  avatar ..> images.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportMermaid(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `erDiagram
+    profiles {
+        any id PK, FK
+        TEXT bio "Shown as 'about me'"
+        any avatar FK
+    }
+    profiles |o--|| users : id
+    profiles }o..|| images : avatar
+`)
+		So(mermaidType("double precision"), ShouldEqual, "double_precision")
+		So(mermaidType("varchar(128)"), ShouldEqual, "varchar(128)")
+	})
+}
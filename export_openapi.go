@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OpenAPITypes is the default mapping of column types to OpenAPI schemas. A
+// type is the name of a JSON type or a schema, like one with a format.
+var OpenAPITypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         `{"type": "integer", "format": "int32"}`,
+		"int":              `{"type": "integer", "format": "int32"}`,
+		"serial":           `{"type": "integer", "format": "int32"}`,
+		"bigint":           `{"type": "integer", "format": "int64"}`,
+		"bigserial":        `{"type": "integer", "format": "int64"}`,
+		"boolean":          "boolean",
+		"real":             `{"type": "number", "format": "float"}`,
+		"float":            `{"type": "number", "format": "double"}`,
+		"double":           `{"type": "number", "format": "double"}`,
+		"double precision": `{"type": "number", "format": "double"}`,
+		"numeric":          "number",
+		"decimal":          "number",
+		"uuid":             `{"type": "string", "format": "uuid"}`,
+		"date":             `{"type": "string", "format": "date"}`,
+		"datetime":         `{"type": "string", "format": "date-time"}`,
+		"timestamp":        `{"type": "string", "format": "date-time"}`,
+		"timestamptz":      `{"type": "string", "format": "date-time"}`,
+		"bytea":            `{"type": "string", "format": "byte"}`,
+		"blob":             `{"type": "string", "format": "byte"}`,
+		"json":             "{}",
+		"jsonb":            "{}",
+	},
+	Default: "string",
+}
+
+// openapiObject is a JSON object which keeps the order of its members.
+type openapiObject []openapiMember
+
+type openapiMember struct {
+	Key   string
+	Value interface{}
+}
+
+func (o openapiObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// openapiPointer escapes a name as a segment of a JSON pointer in a $ref.
+func openapiPointer(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
+
+// openapiType returns the schema of a column of a type of the type map, with
+// the type first. Strings get the length of types like varchar(128) as
+// their maxLength.
+func openapiType(types TypeMap, c Column) (openapiObject, error) {
+	element, array := elementColumn(c)
+	typ, err := schemaType(types.TypeOf(element))
+	if err != nil {
+		return nil, err
+	}
+	var schema openapiObject
+	switch v := typ.(type) {
+	case string:
+		schema = openapiObject{{"type", v}}
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			if key != "type" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		if t, ok := v["type"]; ok {
+			schema = append(schema, openapiMember{"type", t})
+		}
+		for _, key := range keys {
+			schema = append(schema, openapiMember{key, v[key]})
+		}
+	default:
+		return nil, fmt.Errorf("invalid type %s: not an object", types.TypeOf(element))
+	}
+	if args := typeArguments(element); len(schema) > 0 && schema[0].Value == "string" && len(args) == 1 {
+		if length, err := strconv.Atoi(args[0]); err == nil {
+			schema = append(schema, openapiMember{"maxLength", length})
+		}
+	}
+	if array {
+		schema = openapiObject{{"type", "array"}, {"items", schema}}
+	}
+	return schema, nil
+}
+
+// ExportOpenAPI writes an OpenAPI 3.0 document with only components.schemas,
+// to merge into a specification, as YAML or JSON. Each table is an object
+// schema with a property per column, and the columns which are not nullable
+// are required. Relation columns are a $ref to the property they reference,
+// and descriptions become descriptions of the schemas and properties.
+func ExportOpenAPI(p ParsedData, types TypeMap, format string, wr io.Writer) error {
+	if format != "yaml" && format != "json" {
+		return fmt.Errorf("unknown OpenAPI format %q (available: yaml, json)", format)
+	}
+
+	// a table defined twice keeps its first definition
+	var tables []Table
+	names := map[string]string{}
+	unique := uniqueNames{}
+	for _, t := range p.Tables() {
+		if _, ok := names[t.Name]; !ok {
+			names[t.Name] = unique.add(tsName(t.Name))
+			tables = append(tables, t)
+		}
+	}
+	known := map[string]bool{}
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			known[t.Name+"."+c.Name] = true
+		}
+	}
+
+	var schemas openapiObject
+	for _, t := range tables {
+		var required []string
+		var properties openapiObject
+		for _, c := range t.Columns {
+			var property openapiObject
+			if r := c.Relation; r != nil && known[r.TableName+"."+r.ColumnName] {
+				ref := openapiObject{{"$ref", fmt.Sprintf("#/components/schemas/%s/properties/%s",
+					openapiPointer(names[r.TableName]), openapiPointer(r.ColumnName))}}
+				if c.Description == "" && !c.Nullable() {
+					property = ref
+				} else {
+					// OpenAPI 3.0 ignores the siblings of a $ref
+					property = openapiObject{{"allOf", []interface{}{ref}}}
+				}
+			} else {
+				var err error
+				if property, err = openapiType(types, c); err != nil {
+					return fmt.Errorf("line %d: %s.%s: %v", c.Line, t.Name, c.Name, err)
+				}
+			}
+			if c.Nullable() {
+				property = append(property, openapiMember{"nullable", true})
+			} else {
+				required = append(required, c.Name)
+			}
+			if c.Description != "" {
+				property = append(property, openapiMember{"description", c.Description})
+			}
+			properties = append(properties, openapiMember{c.Name, property})
+		}
+
+		schema := openapiObject{{"type", "object"}}
+		if t.Description != "" {
+			schema = append(schema, openapiMember{"description", t.Description})
+		}
+		if len(required) > 0 {
+			schema = append(schema, openapiMember{"required", required})
+		}
+		schema = append(schema, openapiMember{"properties", properties})
+		schemas = append(schemas, openapiMember{names[t.Name], schema})
+	}
+
+	document := openapiObject{{"components", openapiObject{{"schemas", schemas}}}}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	if format == "yaml" {
+		if data, err = yamlFromJSON(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	if _, err := wr.Write(data); err != nil {
+		return err
+	}
+	return nil
+}
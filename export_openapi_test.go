@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportOpenAPI(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportOpenAPI(parseSample(t), OpenAPITypes, "yaml", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.openapi.yaml", buf.Bytes()))
+	})
+
+	Convey("Relations are $refs, nullable columns are not required", t, func() {
+		err, parser := parse(t, `
+posts : Blog posts {
+  id
+  author_id BIGINT NULL -> users.id
+  editor_id -> users.id : who reviewed it
+  blog_id -> blogs.id
+  title varchar(200)
+  tags TEXT[]
+}
+
+users {
+  id
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(ExportOpenAPI(parser, OpenAPITypes, "yaml", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `components:
+  schemas:
+    Posts:
+      type: object
+      description: "Blog posts"
+      required:
+        - id
+        - editor_id
+        - blog_id
+        - title
+        - tags
+      properties:
+        id:
+          type: integer
+          format: int64
+        author_id:
+          allOf:
+            - "$ref": "#/components/schemas/Users/properties/id"
+          nullable: true
+        editor_id:
+          allOf:
+            - "$ref": "#/components/schemas/Users/properties/id"
+          description: "who reviewed it"
+        blog_id:
+          type: integer
+          format: int64
+        title:
+          type: string
+          maxLength: 200
+        tags:
+          type: array
+          items:
+            type: string
+    Users:
+      type: object
+      required:
+        - id
+      properties:
+        id:
+          type: integer
+          format: int64
+`)
+
+		buf.Reset()
+		So(ExportOpenAPI(parser, OpenAPITypes, "json", &buf), ShouldBeNil)
+		var document map[string]map[string]map[string]interface{}
+		So(json.Unmarshal(buf.Bytes(), &document), ShouldBeNil)
+		So(document["components"]["schemas"], ShouldContainKey, "Posts")
+	})
+
+	Convey("Relations to columns of a repeated definition are not $refs", t, func() {
+		err, parser := parse(t, `
+a {
+  id
+}
+
+a {
+  code
+}
+
+b {
+  a_code TEXT -> a.code
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportOpenAPI(parser, OpenAPITypes, "yaml", &buf), ShouldBeNil)
+		So(buf.String(), ShouldNotContainSubstring, "$ref")
+	})
+
+	Convey("Unknown formats are errors", t, func() {
+		err := ExportOpenAPI(parseSample(t), OpenAPITypes, "toml", &bytes.Buffer{})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "available: yaml, json")
+	})
+
+	Convey("JSON becomes block style YAML in order", t, func() {
+		yaml, err := yamlFromJSON([]byte(`{"b": [[1, 2], {"x": null, "y": true}], "a": {}, "c": [], "yes": ""}`))
+		So(err, ShouldBeNil)
+		So(string(yaml), ShouldEqual, `b:
+  - - 1
+    - 2
+  - x: null
+    "y": true
+a: {}
+c: []
+"yes": ""
+`)
+	})
+}
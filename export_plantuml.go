@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func plantumlText(s string) string {
+	return strings.Replace(s, `"`, "'", -1)
+}
+
+func plantumlColumn(c Column) string {
+	line := c.Name
+	if c.Type != "" {
+		line += " : " + c.Type
+	}
+	if c.Relation != nil {
+		line += " <<FK>>"
+	}
+	if c.Description != "" {
+		line += " <i>" + plantumlText(c.Description) + "</i>"
+	}
+	return line
+}
+
+// ExportPlantUML writes the tables as PlantUML entities, with the primary key
+// above the other columns, and the relations in crow's foot notation.
+func ExportPlantUML(p ParsedData, wr io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("@startuml\nhide circle\n")
+	for _, t := range p.Tables() {
+		if t.Description != "" {
+			fmt.Fprintf(&buf, "\nentity \"%s\\n%s\" as %s {\n", t.Name, plantumlText(t.Description), t.Name)
+		} else {
+			fmt.Fprintf(&buf, "\nentity %s {\n", t.Name)
+		}
+		pk := t.PrimaryKey()
+		if pk != nil {
+			fmt.Fprintf(&buf, "  * %s\n  --\n", plantumlColumn(*pk))
+		}
+		for _, c := range t.Columns {
+			if pk == nil || c.Name != pk.Name {
+				fmt.Fprintf(&buf, "  %s\n", plantumlColumn(c))
+			}
+		}
+		buf.WriteString("}\n")
+	}
+
+	buf.WriteString("\n")
+	for _, t := range p.Tables() {
+		for _, c := range t.ColumnsWithRelation() {
+			fmt.Fprintf(&buf, "%s %s %s : %s\n", t.Name, crowsFoot(t, c), c.Relation.TableName, c.Name)
+		}
+	}
+	buf.WriteString("@enduml\n")
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportPlantUML(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportPlantUML(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.puml", buf.Bytes()))
+	})
+
+	Convey("Tables without a primary key", t, func() {
+		err, parser := parse(t, `
+tags : "labels" {
+  name VARCHAR(32) : Unique name
+  post_id ..> posts.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportPlantUML(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `@startuml
+hide circle
+
+entity "tags\n'labels'" as tags {
+  name : VARCHAR(32) <i>Unique name</i>
+  post_id <<FK>>
+}
+
+tags }o..|| posts : post_id
+@enduml
+`)
+	})
+}
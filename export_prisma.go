@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PrismaTypes is the mapping of column types to Prisma scalar types.
+var PrismaTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "Int",
+		"int":              "Int",
+		"serial":           "Int",
+		"bigint":           "BigInt",
+		"bigserial":        "BigInt",
+		"boolean":          "Boolean",
+		"real":             "Float",
+		"float":            "Float",
+		"double":           "Float",
+		"double precision": "Float",
+		"numeric":          "Decimal",
+		"decimal":          "Decimal",
+		"date":             "DateTime",
+		"time":             "DateTime",
+		"datetime":         "DateTime",
+		"timestamp":        "DateTime",
+		"timestamptz":      "DateTime",
+		"json":             "Json",
+		"jsonb":            "Json",
+		"bytea":            "Bytes",
+		"blob":             "Bytes",
+	},
+	Default: "String",
+}
+
+var (
+	prismaIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	prismaSized      = regexp.MustCompile(`^(\w+)\s*\((.*)\)$`)
+)
+
+// prismaNativeNames are the names of the native types of the databases
+// Prisma supports, by column type.
+var prismaNativeNames = map[string]string{
+	"VARCHAR":     "VarChar",
+	"CHAR":        "Char",
+	"NVARCHAR":    "NVarChar",
+	"NCHAR":       "NChar",
+	"DECIMAL":     "Decimal",
+	"NUMERIC":     "Decimal",
+	"TIME":        "Time",
+	"TIMETZ":      "Timetz",
+	"TIMESTAMP":   "Timestamp",
+	"TIMESTAMPTZ": "Timestamptz",
+	"DATETIME":    "DateTime",
+	"DATETIME2":   "DateTime2",
+	"BIT":         "Bit",
+	"VARBIT":      "VarBit",
+	"BINARY":      "Binary",
+	"VARBINARY":   "VarBinary",
+	"FLOAT":       "Float",
+}
+
+// prismaNativeType returns the @db attribute keeping the arguments of a
+// column type, like @db.VarChar(128) of varchar(128), which the Prisma type
+// alone would lose. Only the native types in prismaNativeNames are kept, so
+// display widths like int(11) are dropped.
+func prismaNativeType(c Column) (string, bool) {
+	m := prismaSized.FindStringSubmatch(strings.TrimSpace(c.BaseType()))
+	if m == nil || len(typeArguments(c)) == 0 {
+		return "", false
+	}
+	name, ok := prismaNativeNames[strings.ToUpper(m[1])]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("@db.%s(%s)", name, strings.Join(typeArguments(c), ", ")), true
+}
+
+// prismaModelName returns the name of the model of a table, and whether the
+// table name has to be given by @@map.
+func prismaModelName(table string) (string, bool) {
+	if prismaIdentifier.MatchString(table) {
+		return table, false
+	}
+	return tsName(table), true
+}
+
+// prismaFieldName returns the name of the field of a column, and whether the
+// column name has to be given by @map.
+func prismaFieldName(column string) (string, bool) {
+	if prismaIdentifier.MatchString(column) {
+		return column, false
+	}
+	name := protoField(column)
+	if !prismaIdentifier.MatchString(name) {
+		name = "f" + name
+	}
+	return name, true
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// prismaModel is a model being written.
+type prismaModel struct {
+	*relationType
+	// lines are the fields, as name, type and attributes, preceded by
+	// their descriptions, alone
+	lines [][]string
+}
+
+// prismaRelationName names a relation when there are others between the
+// models, as Prisma requires.
+func prismaRelationName(m *prismaModel, r *relationNames) string {
+	if !r.Ambiguous {
+		return ""
+	}
+	return m.Name + "_" + r.Field
+}
+
+func (m *prismaModel) add(doc string, fields ...string) {
+	if doc != "" {
+		m.lines = append(m.lines, []string{doc})
+	}
+	m.lines = append(m.lines, fields)
+}
+
+// ExportPrisma writes a Prisma model per table. Relations become relation
+// fields with @relation attributes, and the models they point to get the
+// fields back, a list or, when the column is the primary key, an optional
+// model. Columns named id are @id, and referenced columns which are not get
+// @unique. Types with arguments, like varchar(128), keep them as native @db
+// types. Only the models are written, without datasource or generator.
+func ExportPrisma(p ParsedData, wr io.Writer) error {
+	types := newRelationTypes(p.Tables(), func(table string) string {
+		name, _ := prismaModelName(table)
+		return name
+	}, func(column string) string {
+		field, _ := prismaFieldName(column)
+		return field
+	})
+	models := map[string]*prismaModel{}
+	var order []*prismaModel
+	for _, rt := range types {
+		m := &prismaModel{relationType: rt}
+		models[rt.Table.Name] = m
+		order = append(order, m)
+	}
+
+	// relations to columns of known tables, and the referenced columns
+	// which are not primary keys
+	relations := relationFieldNames(types)
+	unique := map[string]bool{}
+	for _, r := range relations {
+		if pk := r.Target.PrimaryKey(); pk == nil || pk.Name != r.Column.Relation.ColumnName {
+			unique[r.Target.Name+"."+r.Column.Relation.ColumnName] = true
+		}
+	}
+
+	for _, m := range order {
+		for _, c := range m.Table.Columns {
+			typ := PrismaTypes.TypeOf(c)
+			if c.Nullable() {
+				typ += "?"
+			}
+			var attributes []string
+			if pk := m.Table.PrimaryKey(); pk != nil && pk.Name == c.Name {
+				attributes = append(attributes, "@id")
+			} else if unique[m.Table.Name+"."+c.Name] {
+				attributes = append(attributes, "@unique")
+			}
+			if native, ok := prismaNativeType(c); ok {
+				attributes = append(attributes, native)
+			}
+			if _, mapped := prismaFieldName(c.Name); mapped {
+				attributes = append(attributes, fmt.Sprintf("@map(%s)", strconv.Quote(c.Name)))
+			}
+			m.add(c.Description, m.Fields[c.Name], typ, strings.Join(attributes, " "))
+		}
+		for _, r := range relations {
+			if r.Table.Name == m.Table.Name {
+				target := models[r.Target.Name]
+				typ := target.Name
+				if r.Column.Nullable() {
+					typ += "?"
+				}
+				args := fmt.Sprintf("fields: [%s], references: [%s]", m.Fields[r.Column.Name], target.Fields[r.Column.Relation.ColumnName])
+				if name := prismaRelationName(m, r); name != "" {
+					args = strconv.Quote(name) + ", " + args
+				}
+				m.add("", r.Field, typ, "@relation("+args+")")
+			}
+		}
+		for _, r := range relations {
+			if r.Target.Name == m.Table.Name {
+				typ := models[r.Table.Name].Name + "[]"
+				if pk := r.Table.PrimaryKey(); pk != nil && pk.Name == r.Column.Name {
+					typ = models[r.Table.Name].Name + "?"
+				}
+				attribute := ""
+				if name := prismaRelationName(models[r.Table.Name], r); name != "" {
+					attribute = fmt.Sprintf("@relation(%s)", strconv.Quote(name))
+				}
+				m.add("", r.BackField, typ, attribute)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, m := range order {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if m.Table.Description != "" {
+			buf.WriteString(docLines("///", m.Table.Description))
+		}
+		fmt.Fprintf(&buf, "model %s {\n", m.Name)
+		nameWidth, typeWidth := 0, 0
+		for _, line := range m.lines {
+			if len(line) == 3 {
+				if w := textCells(line[0]); w > nameWidth {
+					nameWidth = w
+				}
+				if w := textCells(line[1]); w > typeWidth && line[2] != "" {
+					typeWidth = w
+				}
+			}
+		}
+		for _, line := range m.lines {
+			if len(line) == 1 {
+				buf.WriteString(docLines("  ///", line[0]))
+				continue
+			}
+			text := asciiPad(line[0], nameWidth) + " " + line[1]
+			if line[2] != "" {
+				text = asciiPad(text, nameWidth+1+typeWidth) + " " + line[2]
+			}
+			buf.WriteString("  " + text + "\n")
+		}
+		if _, mapped := prismaModelName(m.Table.Name); mapped {
+			fmt.Fprintf(&buf, "\n  @@map(%s)\n", strconv.Quote(m.Table.Name))
+		}
+		buf.WriteString("}\n")
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
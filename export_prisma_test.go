@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportPrisma(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportPrisma(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.prisma", buf.Bytes()))
+	})
+
+	Convey("Relations get both sides, names when ambiguous and unique targets", t, func() {
+		err, parser := parse(t, `
+messages {
+  id
+  sender_id BIGINT NULL -> users.id
+  recipient_id -> users.id
+  code -> users.code
+}
+
+users {
+  id
+  code TEXT
+}
+
+profiles {
+  id -> users.id
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(ExportPrisma(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `model messages {
+  id           BigInt @id
+  sender_id    BigInt?
+  recipient_id BigInt
+  code         String
+  sender       users? @relation("messages_sender", fields: [sender_id], references: [id])
+  recipient    users  @relation("messages_recipient", fields: [recipient_id], references: [id])
+  code_ref     users  @relation("messages_code_ref", fields: [code], references: [code])
+}
+
+model users {
+  id                 BigInt     @id
+  code               String     @unique
+  messages_sender    messages[] @relation("messages_sender")
+  messages_recipient messages[] @relation("messages_recipient")
+  messages_code_ref  messages[] @relation("messages_code_ref")
+  profiles           profiles?
+}
+
+model profiles {
+  id     BigInt @id
+  id_ref users  @relation(fields: [id], references: [id])
+}
+`)
+	})
+
+	Convey("Names Prisma does not allow are mapped", t, func() {
+		var buf bytes.Buffer
+		So(ExportPrisma(Schema{{Name: "ユーザー", Columns: []Column{{Name: "id"}, {Name: "名前"}}}}, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, `@map("名前")`)
+		So(buf.String(), ShouldContainSubstring, `@@map("ユーザー")`)
+	})
+}
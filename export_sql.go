@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type SQLCommentStyle int
+
+const (
+	// CommentOnStatements writes descriptions as COMMENT ON statements.
+	CommentOnStatements SQLCommentStyle = iota
+	// CommentClauses writes descriptions as COMMENT clauses of CREATE TABLE.
+	CommentClauses
+	// CommentLines writes descriptions as -- comments beside the definitions.
+	CommentLines
+)
+
+// SQLDialect describes how a database spells the DDL generated by ExportSQL.
+type SQLDialect struct {
+	Name string
+	// Quote quotes a table or column name.
+	Quote func(name string) string
+	// String quotes a string literal.
+	String func(s string) string
+	// IntegerType and TextType are used for columns without a type; see
+	// sqlColumnType.
+	IntegerType string
+	TextType    string
+	Comments    SQLCommentStyle
+	// ForeignKeyConstraints makes relations FOREIGN KEY table constraints
+	// instead of REFERENCES clauses of the columns.
+	ForeignKeyConstraints bool
+	// AlterForeignKeys tells that foreign keys can be added by ALTER TABLE.
+	// Otherwise they are all declared in CREATE TABLE, even before the
+	// referenced table exists.
+	AlterForeignKeys bool
+}
+
+var SQLDialects = map[string]SQLDialect{
+	"postgres": {
+		Name:             "postgres",
+		Quote:            doubleQuote,
+		String:           sqlString,
+		IntegerType:      "BIGINT",
+		TextType:         "TEXT",
+		Comments:         CommentOnStatements,
+		AlterForeignKeys: true,
+	},
+	// MySQL ignores REFERENCES clauses of columns, so relations are table
+	// constraints.
+	"mysql": {
+		Name:                  "mysql",
+		Quote:                 backQuote,
+		String:                mysqlString,
+		IntegerType:           "BIGINT",
+		TextType:              "VARCHAR(255)",
+		Comments:              CommentClauses,
+		ForeignKeyConstraints: true,
+		AlterForeignKeys:      true,
+	},
+	// SQLite cannot add constraints to existing tables, but checks foreign
+	// keys only when rows are written, so they may reference tables created
+	// later.
+	"sqlite": {
+		Name:        "sqlite",
+		Quote:       doubleQuote,
+		String:      sqlString,
+		IntegerType: "INTEGER",
+		TextType:    "TEXT",
+		Comments:    CommentLines,
+	},
+}
+
+func doubleQuote(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func backQuote(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func sqlString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// mysqlString also escapes backslashes, which start escape sequences in
+// MySQL strings.
+func mysqlString(s string) string {
+	return sqlString(strings.Replace(s, `\`, `\\`, -1))
+}
+
+func sqlCommentLine(s string) string {
+	return "-- " + strings.Join(strings.Fields(s), " ")
+}
+
+// sqlColumnType returns the type of c, guessing one for untyped columns: keys
+// and relations are integers and everything else is text.
+func sqlColumnType(d SQLDialect, c Column) string {
+	switch {
+	case c.Type != "":
+		return c.Type
+	case c.Name == "id", strings.HasSuffix(c.Name, "_id"), c.Relation != nil:
+		return d.IntegerType
+	}
+	return d.TextType
+}
+
+// sqlTableOrder sorts tables so that referenced tables come first. Tables
+// are kept in source order where possible, and cycles are broken by taking
+// the earliest remaining table.
+func sqlTableOrder(tables []Table) []Table {
+	known := map[string]bool{}
+	for _, t := range tables {
+		known[t.Name] = true
+	}
+
+	created := map[string]bool{}
+	ready := func(t Table) bool {
+		for _, c := range t.ColumnsWithRelation() {
+			target := c.Relation.TableName
+			if target != t.Name && known[target] && !created[target] {
+				return false
+			}
+		}
+		return true
+	}
+
+	remaining := append([]Table{}, tables...)
+	var ordered []Table
+	for len(remaining) > 0 {
+		next := 0
+		for i, t := range remaining {
+			if ready(t) {
+				next = i
+				break
+			}
+		}
+		ordered = append(ordered, remaining[next])
+		created[remaining[next].Name] = true
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return ordered
+}
+
+func sqlReferences(d SQLDialect, r *Relation) string {
+	return fmt.Sprintf("REFERENCES %s (%s)", d.Quote(r.TableName), d.Quote(r.ColumnName))
+}
+
+func sqlForeignKey(d SQLDialect, t Table, c Column) string {
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) %s",
+		d.Quote("fk_"+t.Name+"_"+c.Name), d.Quote(c.Name), sqlReferences(d, c.Relation))
+}
+
+// ExportSQL writes CREATE TABLE statements for the tables in the given
+// dialect. Relations become foreign keys, which are added by ALTER TABLE
+// afterwards when the referenced table is created later, as happens with
+// cycles and tables referencing themselves. Descriptions become comments.
+func ExportSQL(p ParsedData, dialect string, wr io.Writer) error {
+	d, ok := SQLDialects[dialect]
+	if !ok {
+		var names []string
+		for name := range SQLDialects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown SQL dialect %q (available: %s)", dialect, strings.Join(names, ", "))
+	}
+
+	var buf, deferred bytes.Buffer
+	created := map[string]bool{}
+	for i, t := range sqlTableOrder(p.Tables()) {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		created[t.Name] = true
+
+		// definitions of the columns and constraints, and the comments
+		// written after them
+		var defs, comments, constraints []string
+		for _, c := range t.Columns {
+			def := d.Quote(c.Name) + " " + sqlColumnType(d, c)
+			if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+				def += " PRIMARY KEY"
+			}
+			if r := c.Relation; r != nil {
+				inline := !d.AlterForeignKeys || (created[r.TableName] && r.TableName != t.Name)
+				switch {
+				case !inline:
+					fmt.Fprintf(&deferred, "ALTER TABLE %s ADD %s;\n", d.Quote(t.Name), sqlForeignKey(d, t, c))
+				case d.ForeignKeyConstraints:
+					constraints = append(constraints, sqlForeignKey(d, t, c))
+				default:
+					def += " " + sqlReferences(d, r)
+				}
+			}
+			comment := ""
+			if c.Description != "" {
+				switch d.Comments {
+				case CommentClauses:
+					def += " COMMENT " + d.String(c.Description)
+				case CommentLines:
+					comment = " " + sqlCommentLine(c.Description)
+				}
+			}
+			defs = append(defs, def)
+			comments = append(comments, comment)
+		}
+		for _, constraint := range constraints {
+			defs = append(defs, constraint)
+			comments = append(comments, "")
+		}
+
+		if t.Description != "" && d.Comments == CommentLines {
+			buf.WriteString(sqlCommentLine(t.Description) + "\n")
+		}
+		fmt.Fprintf(&buf, "CREATE TABLE %s (\n", d.Quote(t.Name))
+		for i, def := range defs {
+			if i < len(defs)-1 {
+				def += ","
+			}
+			buf.WriteString("  " + def + comments[i] + "\n")
+		}
+		buf.WriteString(")")
+		if t.Description != "" && d.Comments == CommentClauses {
+			buf.WriteString(" COMMENT=" + d.String(t.Description))
+		}
+		buf.WriteString(";\n")
+
+		if d.Comments == CommentOnStatements {
+			if t.Description != "" {
+				fmt.Fprintf(&buf, "COMMENT ON TABLE %s IS %s;\n", d.Quote(t.Name), d.String(t.Description))
+			}
+			for _, c := range t.Columns {
+				if c.Description != "" {
+					fmt.Fprintf(&buf, "COMMENT ON COLUMN %s.%s IS %s;\n", d.Quote(t.Name), d.Quote(c.Name), d.String(c.Description))
+				}
+			}
+		}
+	}
+	if deferred.Len() > 0 {
+		buf.WriteString("\n")
+		deferred.WriteTo(&buf)
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
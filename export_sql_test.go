@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportSQL(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportSQL(parseSample(t), "postgres", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.postgres.sql", buf.Bytes()))
+	})
+
+	Convey("sample.erd matches the golden files of the other dialects", t, func() {
+		for _, dialect := range []string{"mysql", "sqlite"} {
+			var buf bytes.Buffer
+			So(ExportSQL(parseSample(t), dialect, &buf), ShouldBeNil)
+			So(buf.String(), ShouldEqual, golden(t, "sample."+dialect+".sql", buf.Bytes()))
+		}
+	})
+
+	Convey("Tables are created after the tables they reference", t, func() {
+		err, parser := parse(t, `
+a {
+  id
+  b_id -> b.id
+}
+
+b {
+  id INT
+  c_id -> c.id
+}
+
+c : It's c {
+  id
+  a_id -> a.id : back to a
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(ExportSQL(parser, "postgres", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `CREATE TABLE "a" (
+  "id" BIGINT PRIMARY KEY,
+  "b_id" INT
+);
+
+CREATE TABLE "c" (
+  "id" BIGINT PRIMARY KEY,
+  "a_id" BIGINT REFERENCES "a" ("id")
+);
+COMMENT ON TABLE "c" IS 'It''s c';
+COMMENT ON COLUMN "c"."a_id" IS 'back to a';
+
+CREATE TABLE "b" (
+  "id" INT PRIMARY KEY,
+  "c_id" BIGINT REFERENCES "c" ("id")
+);
+
+ALTER TABLE "a" ADD CONSTRAINT "fk_a_b_id" FOREIGN KEY ("b_id") REFERENCES "b" ("id");
+`)
+	})
+
+	Convey("MySQL comments and constraints", t, func() {
+		err, parser := parse(t, `
+a : a's \ table {
+  id
+  b_id -> b.id : the b
+  name : a's name
+}
+
+b {
+  id
+  a_id -> a.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportSQL(parser, "mysql", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, "CREATE TABLE `a` (\n"+
+			"  `id` BIGINT PRIMARY KEY,\n"+
+			"  `b_id` BIGINT COMMENT 'the b',\n"+
+			"  `name` VARCHAR(255) COMMENT 'a''s name'\n"+
+			") COMMENT='a''s \\\\ table';\n"+
+			"\n"+
+			"CREATE TABLE `b` (\n"+
+			"  `id` BIGINT PRIMARY KEY,\n"+
+			"  `a_id` BIGINT,\n"+
+			"  CONSTRAINT `fk_b_a_id` FOREIGN KEY (`a_id`) REFERENCES `a` (`id`)\n"+
+			");\n"+
+			"\n"+
+			"ALTER TABLE `a` ADD CONSTRAINT `fk_a_b_id` FOREIGN KEY (`b_id`) REFERENCES `b` (`id`);\n")
+	})
+
+	Convey("SQLite declares every foreign key in CREATE TABLE", t, func() {
+		err, parser := parse(t, `
+a : first {
+  id
+  b_id -> b.id : the b
+  parent_id ..> a.id
+}
+
+b {
+  id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportSQL(parser, "sqlite", &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `CREATE TABLE "b" (
+  "id" INTEGER PRIMARY KEY
+);
+
+-- first
+CREATE TABLE "a" (
+  "id" INTEGER PRIMARY KEY,
+  "b_id" INTEGER REFERENCES "b" ("id"), -- the b
+  "parent_id" INTEGER REFERENCES "a" ("id")
+);
+`)
+	})
+
+	Convey("Unknown dialects are rejected", t, func() {
+		So(ExportSQL(parseSample(t), "oracle", ioutil.Discard), ShouldNotBeNil)
+	})
+}
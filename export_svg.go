@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+)
+
+// SVGOptions changes how RenderSVG draws a diagram.
+type SVGOptions struct {
+	// Link returns the URL a table links to, if set.
+	Link func(table string) string
+}
+
+const svgEdgeBend = 40.0
+
+// svgEdge returns the path of a relation from the row at y1 of box from to
+// the row at y2 of box to. Edges leave and enter the boxes on the sides
+// facing each other, and loop out of the right side between boxes of the
+// same layer.
+func svgEdge(from layoutBox, y1 float64, to layoutBox, y2 float64) string {
+	switch {
+	case to.Layer > from.Layer:
+		x1, x2 := from.X+from.Width, to.X
+		return fmt.Sprintf("M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f", x1, y1, x1+svgEdgeBend, y1, x2-svgEdgeBend, y2, x2, y2)
+	case to.Layer < from.Layer:
+		x1, x2 := from.X, to.X+to.Width
+		return fmt.Sprintf("M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f", x1, y1, x1-svgEdgeBend, y1, x2+svgEdgeBend, y2, x2, y2)
+	}
+	x1, x2 := from.X+from.Width, to.X+to.Width
+	bend := x1
+	if x2 > bend {
+		bend = x2
+	}
+	bend += svgEdgeBend
+	return fmt.Sprintf("M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f", x1, y1, bend, y1, bend, y2, x2, y2)
+}
+
+// RenderSVG lays out the tables and draws them as an SVG image, with a row
+// per column and a solid or dotted arrow per relation. The same tables
+// always give the same image.
+func RenderSVG(tables []Table, opts SVGOptions) []byte {
+	l := layoutTables(tables)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="sans-serif" font-size="12">`+"\n",
+		l.Width, l.Height, l.Width, l.Height)
+	buf.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#586069"/></marker></defs>` + "\n")
+
+	for _, b := range l.Boxes {
+		t := b.Table
+		fmt.Fprintf(&buf, `<g class="table" id="%s">`+"\n", html.EscapeString("table-"+t.Name))
+		if opts.Link != nil {
+			fmt.Fprintf(&buf, `<a href="%s">`+"\n", html.EscapeString(opts.Link(t.Name)))
+		}
+		fmt.Fprintf(&buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#ffffff" stroke="#24292e"/>`+"\n", b.X, b.Y, b.Width, b.Height)
+		fmt.Fprintf(&buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#f6f8fa" stroke="#24292e"/>`+"\n", b.X, b.Y, b.Width, b.headerHeight())
+		textX := b.X + layoutPadding
+		fmt.Fprintf(&buf, `<text x="%.1f" y="%.1f" font-weight="bold" dominant-baseline="middle">%s</text>`+"\n",
+			textX, b.Y+layoutHeaderHeight/2, html.EscapeString(t.Name))
+		if t.Description != "" {
+			fmt.Fprintf(&buf, `<text x="%.1f" y="%.1f" fill="#586069" dominant-baseline="middle">%s</text>`+"\n",
+				textX, b.Y+layoutHeaderHeight+layoutRowHeight/2, html.EscapeString(t.Description))
+		}
+		for _, c := range t.Columns {
+			fmt.Fprintf(&buf, `<text x="%.1f" y="%.1f" dominant-baseline="middle"><tspan font-weight="bold">%s</tspan>`,
+				textX, b.rowY(c.Name), html.EscapeString(c.Name))
+			if c.Type != "" {
+				fmt.Fprintf(&buf, ` <tspan font-style="italic">%s</tspan>`, html.EscapeString(c.Type))
+			}
+			if c.Description != "" {
+				fmt.Fprintf(&buf, ` <tspan fill="#586069">%s</tspan>`, html.EscapeString(c.Description))
+			}
+			buf.WriteString("</text>\n")
+		}
+		if opts.Link != nil {
+			buf.WriteString("</a>\n")
+		}
+		buf.WriteString("</g>\n")
+	}
+
+	for _, from := range l.Boxes {
+		for _, c := range from.Table.ColumnsWithRelation() {
+			to, ok := l.box(c.Relation.TableName)
+			if !ok {
+				continue
+			}
+			dash := ""
+			if c.Relation.LineType == DotLine {
+				dash = ` stroke-dasharray="4,4"`
+			}
+			fmt.Fprintf(&buf, `<path class="relation" d="%s" fill="none" stroke="#586069"%s marker-end="url(#arrow)"><title>%s</title></path>`+"\n",
+				svgEdge(from, from.rowY(c.Name), to, to.rowY(c.Relation.ColumnName)), dash,
+				html.EscapeString(from.Table.Name+"."+c.Name+" -> "+c.Relation.TableName+"."+c.Relation.ColumnName))
+		}
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+func ExportSVG(p ParsedData, wr io.Writer) error {
+	if _, err := wr.Write(RenderSVG(p.Tables(), SVGOptions{})); err != nil {
+		return err
+	}
+	return nil
+}
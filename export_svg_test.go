@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportSVG(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(ExportSVG(parseSample(t), &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.svg", buf.Bytes()))
+
+		decoder := xml.NewDecoder(&buf)
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			So(err, ShouldBeNil)
+		}
+	})
+
+	Convey("Tables are placed left of the tables they reference", t, func() {
+		err, parser := parse(t, `
+a {
+  id
+  b_id -> b.id
+  c_id -> c.id
+}
+
+b {
+  id
+  c_id -> c.id
+}
+
+c {
+  id
+  a_id ..> a.id
+}`)
+		So(err, ShouldBeNil)
+		l := layoutTables(parser.Tables())
+		layers := map[string]int{}
+		for _, b := range l.Boxes {
+			layers[b.Table.Name] = b.Layer
+		}
+		So(layers, ShouldResemble, map[string]int{"a": 0, "b": 1, "c": 2})
+		So(bytes.Equal(RenderSVG(parser.Tables(), SVGOptions{}), RenderSVG(parser.Tables(), SVGOptions{})), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// formatter reprints parsed tables, putting the source comments back next to
+// the table or column they were written beside.
+type formatter struct {
+	buf      bytes.Buffer
+	lines    []string
+	comments []Comment
+	last     int
+}
+
+// blankBefore reports whether the source has an empty line between the last
+// written line and line.
+func (f *formatter) blankBefore(line int) bool {
+	for l := f.last + 1; l < line && l <= len(f.lines); l++ {
+		if strings.TrimSpace(f.lines[l-1]) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// leading writes the comments found before line on their own lines, keeping
+// a single blank line where the source had one unless first is set. It
+// returns whether nothing has been written yet.
+func (f *formatter) leading(line int, indent string, first bool) bool {
+	for len(f.comments) > 0 && f.comments[0].Line < line {
+		c := f.comments[0]
+		f.comments = f.comments[1:]
+		if !first && f.blankBefore(c.Line) {
+			f.buf.WriteString("\n")
+		}
+		f.buf.WriteString(indent + commentLiteral(c) + "\n")
+		f.last = c.Line
+		first = false
+	}
+	return first
+}
+
+// trailing returns the comment written at the end of line, if any.
+func (f *formatter) trailing(line int) string {
+	if len(f.comments) > 0 && f.comments[0].Line == line {
+		c := f.comments[0]
+		f.comments = f.comments[1:]
+		return commentLiteral(c)
+	}
+	return ""
+}
+
+func commentLiteral(c Comment) string {
+	if c.Text == "" {
+		return "#"
+	}
+	return "# " + c.Text
+}
+
+// closingLine finds the line of the brace closing t; it is the first line
+// after the last column that starts with "}".
+func (f *formatter) closingLine(t Table) int {
+	for l := t.Columns[len(t.Columns)-1].Line + 1; l <= len(f.lines); l++ {
+		if strings.HasPrefix(strings.TrimSpace(f.lines[l-1]), "}") {
+			return l
+		}
+	}
+	return len(f.lines)
+}
+
+func tableHeader(t Table) string {
+	if t.Description != "" {
+		return t.Name + " : " + t.Description + " {"
+	}
+	return t.Name + " {"
+}
+
+func relationLiteral(r *Relation) string {
+	if r == nil {
+		return ""
+	}
+	arrow := "->"
+	if r.LineType == DotLine {
+		arrow = "..>"
+	}
+	return arrow + " " + r.TableName + "." + r.ColumnName
+}
+
+// columnLines lays out the columns of a table with their types, relations
+// and descriptions aligned, followed by the trailing comments given in tails.
+func columnLines(columns []Column, tails []string) []string {
+	fields := make([][]string, len(columns))
+	widths := make([]int, 3)
+	for i, c := range columns {
+		tail := tails[i]
+		if c.Description != "" {
+			tail = strings.TrimRight(": "+c.Description+" "+tail, " ")
+		}
+		fields[i] = []string{c.Name, c.Type, relationLiteral(c.Relation), tail}
+		for j := range widths {
+			if w := utf8.RuneCountInString(fields[i][j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	lines := make([]string, len(columns))
+	for i, row := range fields {
+		line := "  "
+		for j, field := range row {
+			if j < len(widths) && widths[j] == 0 {
+				continue
+			}
+			line += field
+			if j < len(widths) {
+				line += strings.Repeat(" ", widths[j]-utf8.RuneCountInString(field)+1)
+			}
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+// FormatSource parses erd source and reprints it canonically: one blank line
+// between tables, two space indentation, aligned column types, relations and
+// descriptions, and comments kept in place.
+func FormatSource(source string) ([]byte, error) {
+	p, err := ParseText(source)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &formatter{
+		lines:    strings.Split(source, "\n"),
+		comments: p.Comments(),
+	}
+	for i, t := range p.Tables() {
+		if i > 0 {
+			f.buf.WriteString("\n")
+		}
+		if !f.leading(t.Line, "", true) && f.blankBefore(t.Line) {
+			f.buf.WriteString("\n")
+		}
+		header := tableHeader(t)
+		if comment := f.trailing(t.Line); comment != "" {
+			header += " " + comment
+		}
+		f.buf.WriteString(header + "\n")
+		f.last = t.Line
+
+		// comments before a column are written before the layout is done,
+		// so lay out each run of columns between them separately.
+		tails := make([]string, len(t.Columns))
+		first := true
+		for start := 0; start < len(t.Columns); {
+			first = f.leading(t.Columns[start].Line, "  ", first)
+			if !first && f.blankBefore(t.Columns[start].Line) {
+				f.buf.WriteString("\n")
+			}
+			end := start
+			for end < len(t.Columns) {
+				c := t.Columns[end]
+				if end > start && (f.hasCommentBefore(c.Line) || f.blankBefore(c.Line)) {
+					break
+				}
+				tails[end] = f.trailing(c.Line)
+				f.last = c.Line
+				end++
+			}
+			for _, line := range columnLines(t.Columns[start:end], tails[start:end]) {
+				f.buf.WriteString(line + "\n")
+			}
+			first = false
+			start = end
+		}
+
+		closing := f.closingLine(t)
+		f.leading(closing, "  ", false)
+		f.buf.WriteString(strings.TrimRight("} "+f.trailing(closing), " ") + "\n")
+		f.last = closing
+	}
+	f.leading(len(f.lines)+1, "", len(p.Tables()) == 0)
+
+	return f.buf.Bytes(), nil
+}
+
+func (f *formatter) hasCommentBefore(line int) bool {
+	return len(f.comments) > 0 && f.comments[0].Line < line
+}
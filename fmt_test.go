@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormatSource(t *testing.T) {
+	Convey("Columns are aligned and spacing normalized", t, func() {
+		formatted, err := FormatSource(`
+devices :   devices including iOS/Android   {
+    id  BIGINT
+  user_id -> users.id :  User of the device.
+  token : Unique token.
+}
+users
+{
+ id
+}`)
+		So(err, ShouldBeNil)
+		So(string(formatted), ShouldEqual, `devices : devices including iOS/Android {
+  id      BIGINT
+  user_id        -> users.id : User of the device.
+  token                      : Unique token.
+}
+
+users {
+  id
+}
+`)
+	})
+
+	Convey("Comments are kept in place", t, func() {
+		formatted, err := FormatSource(`# header
+
+# devices
+devices { # all devices
+  id   # primary key
+  # the owner
+  user_id ..> users.id
+
+  token   : the token   # secret
+  # more to come
+}  # end
+# trailing
+`)
+		So(err, ShouldBeNil)
+		So(string(formatted), ShouldEqual, `# header
+
+# devices
+devices { # all devices
+  id # primary key
+  # the owner
+  user_id ..> users.id
+
+  token : the token # secret
+  # more to come
+} # end
+# trailing
+`)
+	})
+
+	Convey("Formatting is idempotent", t, func() {
+		source, err := ioutil.ReadFile("sample.erd")
+		So(err, ShouldBeNil)
+		once, err := FormatSource(string(source))
+		So(err, ShouldBeNil)
+		twice, err := FormatSource(string(once))
+		So(err, ShouldBeNil)
+		So(string(twice), ShouldEqual, string(once))
+	})
+
+	Convey("Syntax errors are returned", t, func() {
+		_, err := FormatSource("devices {")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	Convey("Equal input has no diff", t, func() {
+		So(UnifiedDiff("a.erd", []byte("a\nb\n"), []byte("a\nb\n")), ShouldBeNil)
+	})
+
+	Convey("Changed lines are shown with context", t, func() {
+		diff := UnifiedDiff("a.erd", []byte("a\nb\nc\n"), []byte("a\nB\nc\nd\n"))
+		So(string(diff), ShouldEqual, `--- a.erd.orig
++++ a.erd
+@@ -1,3 +1,4 @@
+ a
+-b
++B
+ c
++d
+`)
+	})
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// parseTimeout bounds the time to parse a single fuzz input.
+const parseTimeout = 2 * time.Second
+
+var fuzzSeeds = []string{
+	"",
+	"\n\n",
+	"# only a comment",
+	"a {\n  b\n}",
+	"a{\nb\n}",
+	"a :desc{\n\tb\n}",
+	"a : {\n  b\n}",
+	"a : x {\n  b :\n}",
+	"a {\n  b INT\n}",
+	"a {\n  b\tDOUBLE PRECISION  \n}",
+	"a {\n  b varchar(128) -> c.d : e\n}",
+	"a {\n  b ..> c.d\n}",
+	"a {\n  b -> c.d #x\n}",
+	"a {\n  b # x\n} # y\n#z",
+	"a {\n  b : # not a comment\n}",
+	"a {\n  b }\n}",
+	"a {\n  b -> c.\n}",
+	"a {\n  b -> .d\n}",
+	"a {\n  b -> c.d }",
+	"a {\n}",
+	"a {\n  b\n",
+	"{\n  b\n}",
+	"テーブル {\n  b\n}",
+	"a : 説明 {\n  b 型 : 説明\n}",
+	"a {\n  b\r\n}\r\n",
+	"a {\n  b \xff\n}",
+}
+
+func addFuzzSeeds(f *testing.F) {
+	sample, err := ioutil.ReadFile("sample.erd")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(sample))
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+}
+
+// parseWithTimeout parses text, failing the test if it takes too long.
+func parseWithTimeout(t *testing.T, text string) (*Parser, error) {
+	type result struct {
+		parser *Parser
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		parser, err := ParseText(text)
+		done <- result{parser, err}
+	}()
+	select {
+	case r := <-done:
+		return r.parser, r.err
+	case <-time.After(parseTimeout):
+		t.Fatalf("parsing %q took more than %v", text, parseTimeout)
+	}
+	return nil, nil
+}
+
+func commentTexts(comments []Comment) []string {
+	var texts []string
+	for _, c := range comments {
+		texts = append(texts, c.Text)
+	}
+	return texts
+}
+
+func FuzzParse(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		parser, err := parseWithTimeout(t, text)
+		if err != nil {
+			return
+		}
+
+		var exported bytes.Buffer
+		if err := ExportERD(parser, &exported); err != nil {
+			t.Fatalf("cannot export parsed %q: %v", text, err)
+		}
+		reparsed, err := ParseText(exported.String())
+		if err != nil {
+			t.Fatalf("cannot parse exported %q: %v", exported.String(), err)
+		}
+		if !reflect.DeepEqual(withoutLines(parser.Tables()), withoutLines(reparsed.Tables())) {
+			t.Fatalf("tables of %q changed after export:\n%#v\n%#v", text, parser.Tables(), reparsed.Tables())
+		}
+
+		var again bytes.Buffer
+		if err := ExportERD(reparsed, &again); err != nil {
+			t.Fatal(err)
+		}
+		if again.String() != exported.String() {
+			t.Fatalf("export is not stable:\n%s\n%s", exported.String(), again.String())
+		}
+	})
+}
+
+func FuzzFormat(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, text string) {
+		parser, err := parseWithTimeout(t, text)
+		formatted, ferr := FormatSource(text)
+		if (err == nil) != (ferr == nil) {
+			t.Fatalf("parse error %v but format error %v", err, ferr)
+		}
+		if err != nil {
+			return
+		}
+
+		reparsed, err := ParseText(string(formatted))
+		if err != nil {
+			t.Fatalf("cannot parse formatted %q: %v", formatted, err)
+		}
+		if !reflect.DeepEqual(withoutLines(parser.Tables()), withoutLines(reparsed.Tables())) {
+			t.Fatalf("tables of %q changed after formatting:\n%#v\n%#v", text, parser.Tables(), reparsed.Tables())
+		}
+		if !reflect.DeepEqual(commentTexts(parser.Comments()), commentTexts(reparsed.Comments())) {
+			t.Fatalf("comments of %q changed after formatting:\n%q", text, formatted)
+		}
+
+		twice, err := FormatSource(string(formatted))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(formatted, twice) {
+			t.Fatalf("formatting is not idempotent:\n%s\n%s", formatted, twice)
+		}
+	})
+}
+
+var dbmlFuzzSeeds = []string{
+	"Table a {\n  b int\n}",
+	"Table a as A [note: 'x'] {\n  b int [pk, ref: > A.b]\n  Note: 'y'\n}",
+	"Table \"a b\" {\n  \"c d\" \"double precision\" [note: 'it\\'s']\n}",
+	"Table a {\n  b decimal(1, 2)\n  c int[]\n  indexes {\n    (b, c)\n  }\n}",
+	"Table a {\n  b int\n}\nRef: a.b < a.b\nRef x {\n  a.b - a.b\n}",
+	"Table a {\n  Note {\n    '''\n    x\n    '''\n  }\n  b int // c\n}\n/* d */",
+	"Enum e {\n  x [note: 'y']\n}\nProject p {\n  Note: 'z'\n}",
+	"Table a {\n  b int [default: `now()`, note: \"x\"]\n}",
+	"Table a {\n  b\n}",
+	"Table a {",
+	"Ref: a.b <> c.d",
+	"Table a{w([",
+}
+
+// FuzzParseDBML checks that ParseDBML neither panics nor hangs, and that what
+// it reads survives a round trip through ExportDBML.
+func FuzzParseDBML(f *testing.F) {
+	sample, err := ioutil.ReadFile("testdata/sample.dbml")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(sample))
+	for _, seed := range dbmlFuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		type result struct {
+			schema Schema
+			err    error
+		}
+		done := make(chan result, 1)
+		go func() {
+			schema, err := ParseDBML(text)
+			done <- result{schema, err}
+		}()
+		var r result
+		select {
+		case r = <-done:
+		case <-time.After(parseTimeout):
+			t.Fatalf("parsing %q took more than %v", text, parseTimeout)
+		}
+		if r.err != nil {
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := ExportDBML(r.schema, &buf); err != nil {
+			t.Fatal(err)
+		}
+		again, err := ParseDBML(buf.String())
+		if err != nil {
+			t.Fatalf("parsing the export of %q: %v\n%s", text, err, buf.String())
+		}
+		if !reflect.DeepEqual(withoutLines(again), withoutLines(r.schema)) {
+			t.Fatalf("round trip of %q changed the tables:\n%s", text, buf.String())
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli"
+)
+
+// TypeMap maps column types to the types of a generated language. Column
+// types are matched after normalizeType, so "double precision" matches
+// "DOUBLE PRECISION", and a type with parameters like varchar(128) also
+// matches the type without them.
+type TypeMap struct {
+	Types map[string]string `json:"types"`
+	// Default is the type of columns whose type is not in Types.
+	Default string `json:"default"`
+}
+
+var typeParameters = regexp.MustCompile(`\(.*\)$`)
+
+// Lookup returns the type a column type maps to.
+func (m TypeMap) Lookup(columnType string) (string, bool) {
+	normalized := normalizeType(columnType)
+	for _, t := range []string{normalized, typeParameters.ReplaceAllString(normalized, "")} {
+		for key, value := range m.Types {
+			if normalizeType(key) == t {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// TypeOf returns the type of a column, ignoring its nullability. Untyped
+// columns are BIGINT when they are keys or relations, as in sqlColumnType,
+// and Default otherwise.
+func (m TypeMap) TypeOf(c Column) string {
+	columnType := c.BaseType()
+	if columnType == "" && (c.Name == "id" || strings.HasSuffix(c.Name, "_id") || c.Relation != nil) {
+		columnType = "BIGINT"
+	}
+	if t, ok := m.Lookup(columnType); ok {
+		return t
+	}
+	return m.Default
+}
+
+// schemaType returns a type of a type map for a schema format, which is
+// either a name, like long, or a JSON schema, like {"type": "long",
+// "logicalType": "date"}, decoded.
+func schemaType(typ string) (interface{}, error) {
+	typ = strings.TrimSpace(typ)
+	if !strings.HasPrefix(typ, "{") && !strings.HasPrefix(typ, "[") {
+		return typ, nil
+	}
+	var schema interface{}
+	if err := json.Unmarshal([]byte(typ), &schema); err != nil {
+		return nil, fmt.Errorf("invalid type %s: %v", typ, err)
+	}
+	return schema, nil
+}
+
+// LoadTypeMap reads a JSON file like {"types": {"uuid": "string"}} and
+// returns base with the types of the file added or replaced.
+func LoadTypeMap(path string, base TypeMap) (TypeMap, error) {
+	var file TypeMap
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return base, err
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return base, fmt.Errorf("%s: %v", path, err)
+	}
+
+	m := TypeMap{Types: map[string]string{}, Default: base.Default}
+	for key, value := range base.Types {
+		m.Types[normalizeType(key)] = value
+	}
+	for key, value := range file.Types {
+		m.Types[normalizeType(key)] = value
+	}
+	if file.Default != "" {
+		m.Default = file.Default
+	}
+	return m, nil
+}
+
+// nameWords splits a table or column name into words at underscores, other
+// punctuation and changes from lower to upper case.
+func nameWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 &&
+			(unicode.IsLower(word[len(word)-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(word[len(word)-1])):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// pluralize guesses the English plural of the last word of name, leaving
+// plurals as they are.
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case isPlural(name):
+		return name
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// uniqueNames makes names unique by numbering the repeated ones.
+type uniqueNames map[string]bool
+
+func (u uniqueNames) add(name string) string {
+	unique := name
+	for i := 2; u[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	u[unique] = true
+	return unique
+}
+
+// docLines returns a description as lines of comments starting with prefix.
+func docLines(prefix, description string) string {
+	var buf bytes.Buffer
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		buf.WriteString(strings.TrimRight(prefix+" "+strings.TrimSpace(line), " ") + "\n")
+	}
+	return buf.String()
+}
+
+// genCommand returns a subcommand of gen which parses an erd file and
+// writes the code generate makes of it to the output. Its types are types
+// overridden by the --types file. written, if not nil, is called once the
+// output is written, to save what must only change along with it.
+func genCommand(name, usage string, types TypeMap, flags []cli.Flag,
+	generate func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error,
+	written func(c *cli.Context) error) cli.Command {
+	return cli.Command{
+		Name:      name,
+		Usage:     usage,
+		ArgsUsage: "[file]",
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "o, output",
+				Usage: "file to write to instead of stdout.",
+			},
+			cli.StringFlag{
+				Name:  "types",
+				Usage: "JSON file mapping column types to generated types, like {\"types\": {\"uuid\": \"string\"}}.",
+			},
+		}, flags...),
+		Action: func(c *cli.Context) error {
+			_, text, err := ReadSource(c)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			parser, err := ParseText(text)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+
+			for _, mismatch := range ResolveTypes(parser.Tables()) {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", mismatch)
+			}
+
+			m := types
+			if path := c.String("types"); path != "" {
+				if m, err = LoadTypeMap(path, types); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+			}
+
+			var buf bytes.Buffer
+			if err := generate(c, parser, m, &buf); err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			if path := c.String("output"); path != "" {
+				err = ioutil.WriteFile(path, buf.Bytes(), 0644)
+			} else {
+				_, err = os.Stdout.Write(buf.Bytes())
+			}
+			if err == nil && written != nil {
+				err = written(c)
+			}
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DjangoTypes is the default mapping of column types to Django model fields.
+// Names without a module are fields of django.db.models, and others are
+// imported from their module. CharField takes its max_length and
+// DecimalField its digits from the column type, like varchar(128) and
+// decimal(10, 2), and become TextField and FloatField without them.
+var DjangoTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "SmallIntegerField",
+		"int":              "IntegerField",
+		"serial":           "AutoField",
+		"bigint":           "BigIntegerField",
+		"bigserial":        "BigAutoField",
+		"boolean":          "BooleanField",
+		"real":             "FloatField",
+		"float":            "FloatField",
+		"double":           "FloatField",
+		"double precision": "FloatField",
+		"numeric":          "DecimalField",
+		"decimal":          "DecimalField",
+		"text":             "TextField",
+		"varchar":          "CharField",
+		"char":             "CharField",
+		"uuid":             "UUIDField",
+		"date":             "DateField",
+		"time":             "TimeField",
+		"datetime":         "DateTimeField",
+		"timestamp":        "DateTimeField",
+		"timestamptz":      "DateTimeField",
+		"json":             "JSONField",
+		"jsonb":            "JSONField",
+		"bytea":            "BinaryField",
+		"blob":             "BinaryField",
+	},
+	Default: "TextField",
+}
+
+// djangoField returns the field and the arguments of a column which is not
+// a foreign key.
+func djangoField(imports pythonImports, types TypeMap, c Column) (string, []string) {
+	field := types.TypeOf(c)
+	params := typeArguments(c)
+	var args []string
+	switch {
+	case field == "CharField" && len(params) == 1:
+		args = append(args, "max_length="+params[0])
+	case field == "CharField":
+		field = "TextField"
+	case field == "DecimalField" && len(params) == 2:
+		args = append(args, "max_digits="+params[0], "decimal_places="+params[1])
+	case field == "DecimalField":
+		field = "FloatField"
+	}
+	if !strings.Contains(field, ".") {
+		return "models." + field, args
+	}
+	return imports.use("", field), args
+}
+
+// GenerateDjango writes a Python module with a Django model per table, named
+// after the table by its Meta. Relations to known tables become ForeignKey
+// fields, or OneToOneField for primary keys, which do nothing on delete as
+// the database is defined elsewhere. Nullable columns are null=True, and
+// descriptions become docstrings of the models and help texts of the fields.
+func GenerateDjango(p ParsedData, types TypeMap, wr io.Writer) error {
+	imports := pythonImports{}
+	imports.add("django.db", "models")
+
+	classes := map[string]string{}
+	unique := uniqueNames{}
+	for _, t := range p.Tables() {
+		if _, ok := classes[t.Name]; !ok {
+			classes[t.Name] = unique.add(tsName(t.Name))
+		}
+	}
+
+	var body bytes.Buffer
+	for _, t := range p.Tables() {
+		fmt.Fprintf(&body, "\n\nclass %s(models.Model):\n", classes[t.Name])
+		if t.Description != "" {
+			body.WriteString(pythonDocstring("    ", t.Description) + "\n")
+		}
+
+		// foreign keys from the table to each table, which need related
+		// names when there are several
+		targets := map[string]int{}
+		for _, c := range t.ColumnsWithRelation() {
+			targets[c.Relation.TableName]++
+		}
+
+		attributes := uniqueNames{"objects": true, "pk": true}
+		for _, c := range t.Columns {
+			pk := t.PrimaryKey()
+			isPK := pk != nil && pk.Name == c.Name
+			r := c.Relation
+			target, known := "", false
+			if r != nil {
+				target, known = classes[r.TableName]
+			}
+
+			var field string
+			var args []string
+			var name, column string
+			if known {
+				// Django adds _id to the name of the field for its column
+				name = attributes.add(pythonName(strings.TrimSuffix(c.Name, "_id")))
+				column = name + "_id"
+				field = "models.ForeignKey"
+				if isPK {
+					field = "models.OneToOneField"
+				}
+				if target == classes[t.Name] {
+					target = "self"
+				}
+				args = append(args, pythonString(target), "on_delete=models.DO_NOTHING")
+				if tp := targetPrimaryKey(p.Tables(), r.TableName); tp == nil || tp.Name != r.ColumnName {
+					args = append(args, "to_field="+pythonString(r.ColumnName))
+				}
+				if targets[r.TableName] > 1 {
+					args = append(args, "related_name="+pythonString(t.Name+"_"+name+"_set"))
+				}
+			} else {
+				name = attributes.add(pythonName(c.Name))
+				column = name
+				field, args = djangoField(imports, types, c)
+			}
+			if isPK {
+				args = append(args, "primary_key=True")
+			}
+			if column != c.Name {
+				args = append(args, "db_column="+pythonString(c.Name))
+			}
+			if c.Nullable() {
+				args = append(args, "null=True")
+			}
+			if c.Description != "" {
+				args = append(args, "help_text="+pythonString(c.Description))
+			}
+			fmt.Fprintf(&body, "    %s = %s(%s)\n", name, field, strings.Join(args, ", "))
+		}
+		fmt.Fprintf(&body, "\n    class Meta:\n        db_table = %s\n", pythonString(t.Name))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by erd gen django. DO NOT EDIT.\n\n")
+	buf.WriteString(imports.String())
+	body.WriteTo(&buf)
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// targetPrimaryKey returns the primary key of the table named name, if any.
+func targetPrimaryKey(tables []Table, name string) *Column {
+	for _, t := range tables {
+		if t.Name == name {
+			return t.PrimaryKey()
+		}
+	}
+	return nil
+}
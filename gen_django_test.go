@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateDjango(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(GenerateDjango(parseSample(t), DjangoTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.django.py", buf.Bytes()))
+	})
+
+	Convey("Foreign keys, parameters and nullable columns", t, func() {
+		err, parser := parse(t, `
+messages : Messages {
+  id
+  sender_id -> users.id
+  recipient_id -> users.id : who gets it
+  reply_to_id BIGINT NULL -> messages.id
+  subject varchar(200)
+  body text NULL
+  price decimal(10, 2)
+  ratio decimal
+  author_code -> users.code
+  blog_id -> blogs.id
+}
+
+users {
+  id
+  code varchar(16)
+}
+
+profiles {
+  id -> users.id
+  class
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(GenerateDjango(parser, DjangoTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `# Code generated by erd gen django. DO NOT EDIT.
+
+from django.db import models
+
+
+class Messages(models.Model):
+    """Messages"""
+
+    id = models.BigIntegerField(primary_key=True)
+    sender = models.ForeignKey("Users", on_delete=models.DO_NOTHING, related_name="messages_sender_set")
+    recipient = models.ForeignKey("Users", on_delete=models.DO_NOTHING, related_name="messages_recipient_set", help_text="who gets it")
+    reply_to = models.ForeignKey("self", on_delete=models.DO_NOTHING, null=True)
+    subject = models.CharField(max_length=200)
+    body = models.TextField(null=True)
+    price = models.DecimalField(max_digits=10, decimal_places=2)
+    ratio = models.FloatField()
+    author_code = models.ForeignKey("Users", on_delete=models.DO_NOTHING, to_field="code", related_name="messages_author_code_set", db_column="author_code")
+    blog_id = models.BigIntegerField()
+
+    class Meta:
+        db_table = "messages"
+
+
+class Users(models.Model):
+    id = models.BigIntegerField(primary_key=True)
+    code = models.CharField(max_length=16)
+
+    class Meta:
+        db_table = "users"
+
+
+class Profiles(models.Model):
+    id = models.OneToOneField("Users", on_delete=models.DO_NOTHING, primary_key=True, db_column="id")
+    class_ = models.TextField(db_column="class")
+
+    class Meta:
+        db_table = "profiles"
+`)
+	})
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoTypes is the default mapping of column types to Go types. A type in
+// another package is written with the import path of the package, like
+// "github.com/google/uuid.UUID".
+var GoTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "int16",
+		"int":              "int32",
+		"serial":           "int32",
+		"bigint":           "int64",
+		"bigserial":        "int64",
+		"boolean":          "bool",
+		"real":             "float32",
+		"float":            "float64",
+		"double":           "float64",
+		"double precision": "float64",
+		"numeric":          "float64",
+		"decimal":          "float64",
+		"text":             "string",
+		"varchar":          "string",
+		"char":             "string",
+		"uuid":             "string",
+		"date":             "time.Time",
+		"time":             "time.Time",
+		"datetime":         "time.Time",
+		"timestamp":        "time.Time",
+		"timestamptz":      "time.Time",
+		"json":             "encoding/json.RawMessage",
+		"jsonb":            "encoding/json.RawMessage",
+		"bytea":            "[]byte",
+		"blob":             "[]byte",
+	},
+	Default: "string",
+}
+
+// goInitialisms are the words Go names spell in capitals.
+var goInitialisms = map[string]bool{
+	"API": true, "CSS": true, "DB": true, "DNS": true, "HTML": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "UI": true,
+	"URI": true, "URL": true, "UTF8": true, "UUID": true, "XML": true,
+}
+
+// goName makes an exported Go name of a table or column name, like UserID
+// of user_id.
+func goName(name string) string {
+	var sb strings.Builder
+	for _, word := range nameWords(name) {
+		if upper := strings.ToUpper(word); goInitialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	s := sb.String()
+	if s == "" || !unicode.IsUpper([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+// goType splits a type of GoTypes into the type as written in Go and the
+// import path of its package, if any.
+func goType(t string) (string, string) {
+	prefix := strings.TrimLeft(t, "*[]")
+	name := prefix
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return t, ""
+	}
+	path := name[:dot]
+	pkg := path[strings.LastIndex(path, "/")+1:]
+	return t[:len(t)-len(prefix)] + pkg + name[dot:], path
+}
+
+func goTag(c Column) string {
+	tag := fmt.Sprintf("db:%s json:%s", strconv.Quote(c.Name), strconv.Quote(c.Name))
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// GenerateGo writes a Go file of package pkg with a struct per table, and a
+// field with db and json tags per column. Nullable columns are pointers, and
+// descriptions become doc comments.
+func GenerateGo(p ParsedData, pkg string, types TypeMap, wr io.Writer) error {
+	var body bytes.Buffer
+	imports := map[string]bool{}
+	structs := uniqueNames{}
+	for _, t := range p.Tables() {
+		name := structs.add(goName(t.Name))
+		fmt.Fprintf(&body, "\n// %s is a row of the table %s.\n", name, t.Name)
+		if t.Description != "" {
+			body.WriteString("//\n" + docLines("//", t.Description))
+		}
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+		fields := uniqueNames{}
+		for _, c := range t.Columns {
+			typ, path := goType(types.TypeOf(c))
+			if c.Nullable() && !strings.HasPrefix(typ, "*") && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") {
+				typ = "*" + typ
+			}
+			if path != "" {
+				imports[path] = true
+			}
+			if c.Description != "" {
+				body.WriteString(docLines("\t//", c.Description))
+			}
+			fmt.Fprintf(&body, "\t%s %s %s\n", fields.add(goName(c.Name)), typ, goTag(c))
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by erd gen go. DO NOT EDIT.\n\npackage %s\n", pkg)
+	if len(imports) > 0 {
+		var paths []string
+		for path := range imports {
+			paths = append(paths, strconv.Quote(path))
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(&buf, "\nimport (\n\t%s\n)\n", strings.Join(paths, "\n\t"))
+	}
+	body.WriteTo(&buf)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated invalid Go code: %v", err)
+	}
+	if _, err := wr.Write(src); err != nil {
+		return err
+	}
+	return nil
+}
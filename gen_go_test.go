@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateGo(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(GenerateGo(parseSample(t), "models", GoTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.go", buf.Bytes()))
+	})
+
+	Convey("Names, types and imports", t, func() {
+		So(goName("user_id"), ShouldEqual, "UserID")
+		So(goName("api_url"), ShouldEqual, "APIURL")
+		So(goName("BlogPosts"), ShouldEqual, "BlogPosts")
+		So(goName("2fa"), ShouldEqual, "X2fa")
+		So(goName("名前"), ShouldEqual, "X名前")
+
+		typ, path := goType("*github.com/google/uuid.UUID")
+		So(typ, ShouldEqual, "*uuid.UUID")
+		So(path, ShouldEqual, "github.com/google/uuid")
+		typ, path = goType("[]byte")
+		So(typ, ShouldEqual, "[]byte")
+		So(path, ShouldEqual, "")
+	})
+
+	Convey("Types in other packages are imported", t, func() {
+		types := TypeMap{Types: map[string]string{
+			"timestamp": "time.Time",
+			"uuid":      "github.com/google/uuid.UUID",
+		}, Default: "string"}
+		schema := Schema{{Name: "events", Columns: []Column{
+			{Name: "id", Type: "uuid"},
+			{Name: "at", Type: "TIMESTAMP", Description: "when it happened"},
+			{Name: "At", Description: "a column named like another"},
+			{Name: "`quoted`"},
+		}}}
+		var buf bytes.Buffer
+		So(GenerateGo(schema, "events", types, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, "// Code generated by erd gen go. DO NOT EDIT.\n"+`
+package events
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Events is a row of the table events.
+type Events struct {
+	ID uuid.UUID `+"`"+`db:"id" json:"id"`+"`"+`
+	// when it happened
+	At time.Time `+"`"+`db:"at" json:"at"`+"`"+`
+	// a column named like another
+	At2    string `+"`"+`db:"At" json:"At"`+"`"+`
+	Quoted string "db:\"`+"`quoted`"+`\" json:\"`+"`quoted`"+`\""
+}
+`)
+	})
+
+	Convey("Nullable columns are pointers", t, func() {
+		schema := Schema{{Name: "users", Columns: []Column{
+			{Name: "bio", Type: "text NULL"},
+			{Name: "avatar", Type: "bytea NULL"},
+			{Name: "name", Type: "text NOT NULL"},
+		}}}
+		var buf bytes.Buffer
+		So(GenerateGo(schema, "models", GoTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "\tBio    *string ")
+		So(buf.String(), ShouldContainSubstring, "\tAvatar []byte ")
+		So(buf.String(), ShouldContainSubstring, "\tName   string ")
+	})
+
+	Convey("Invalid package names are errors", t, func() {
+		var buf bytes.Buffer
+		So(GenerateGo(parseSample(t), "not a package", GoTypes, &buf), ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// ProtoTypes is the default mapping of column types to protobuf types.
+var ProtoTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "int32",
+		"int":              "int32",
+		"serial":           "int32",
+		"bigint":           "int64",
+		"bigserial":        "int64",
+		"boolean":          "bool",
+		"real":             "float",
+		"float":            "double",
+		"double":           "double",
+		"double precision": "double",
+		"numeric":          "double",
+		"decimal":          "double",
+		"bytea":            "bytes",
+		"blob":             "bytes",
+		"date":             "google.protobuf.Timestamp",
+		"datetime":         "google.protobuf.Timestamp",
+		"timestamp":        "google.protobuf.Timestamp",
+		"timestamptz":      "google.protobuf.Timestamp",
+	},
+	Default: "string",
+}
+
+// protoImports are the files defining the well-known types.
+var protoImports = map[string]string{
+	"google.protobuf.Any":       "google/protobuf/any.proto",
+	"google.protobuf.Duration":  "google/protobuf/duration.proto",
+	"google.protobuf.Struct":    "google/protobuf/struct.proto",
+	"google.protobuf.Value":     "google/protobuf/struct.proto",
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+}
+
+// protoMaxField is the largest field number, and protoReservedFields the
+// numbers protobuf keeps for itself.
+const protoMaxField = 1<<29 - 1
+
+var protoReservedFields = [2]int{19000, 19999}
+
+var (
+	protoPackage    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+	protoIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ProtoLock records the field numbers given to the columns, so that
+// regenerating a .proto file never renumbers a field. Columns are never
+// removed from it: the numbers of dropped columns stay reserved.
+type ProtoLock struct {
+	// Messages maps table names to column names to field numbers.
+	Messages map[string]map[string]int `json:"messages"`
+}
+
+// LoadProtoLock reads a lock file, or returns an empty lock if there is
+// none yet.
+func LoadProtoLock(path string) (ProtoLock, error) {
+	lock := ProtoLock{Messages: map[string]map[string]int{}}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return lock, err
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return lock, fmt.Errorf("%s: %v", path, err)
+	}
+	if lock.Messages == nil {
+		lock.Messages = map[string]map[string]int{}
+	}
+	for table, fields := range lock.Messages {
+		numbers := map[int]string{}
+		for column, number := range fields {
+			if other, ok := numbers[number]; ok {
+				return lock, fmt.Errorf("%s: %s.%s and %s.%s have the same number %d", path, table, column, table, other, number)
+			}
+			numbers[number] = column
+		}
+	}
+	return lock, nil
+}
+
+// Save writes the lock file.
+func (l ProtoLock) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// number returns the field number of a column, giving it the number after
+// the largest one of the table if it has none.
+func (l ProtoLock) number(table, column string) (int, error) {
+	fields := l.Messages[table]
+	if fields == nil {
+		fields = map[string]int{}
+		l.Messages[table] = fields
+	}
+	if number, ok := fields[column]; ok {
+		return number, nil
+	}
+	next := 1
+	for _, number := range fields {
+		if number >= next {
+			next = number + 1
+		}
+	}
+	if next >= protoReservedFields[0] && next <= protoReservedFields[1] {
+		next = protoReservedFields[1] + 1
+	}
+	if next > protoMaxField {
+		return 0, fmt.Errorf("no field number left for %s.%s", table, column)
+	}
+	fields[column] = next
+	return next, nil
+}
+
+// protoField makes a field name of a column name, like blog_id of blogId.
+func protoField(name string) string {
+	s := strings.ToLower(strings.Join(nameWords(name), "_"))
+	if !protoIdentifier.MatchString(s) {
+		s = "field_" + s
+	}
+	if !protoIdentifier.MatchString(s) {
+		s = "field"
+	}
+	return s
+}
+
+// GenerateProto writes a proto3 file with a message per table and a field per
+// column, numbered as recorded in lock. Columns new to the lock are added to
+// it, and the numbers of columns which are gone are reserved. Nullable
+// columns are optional fields, and descriptions become comments.
+func GenerateProto(p ParsedData, pkg string, types TypeMap, lock ProtoLock, wr io.Writer) error {
+	if pkg != "" && !protoPackage.MatchString(pkg) {
+		return fmt.Errorf("invalid protobuf package %q", pkg)
+	}
+
+	var body bytes.Buffer
+	imports := map[string]bool{}
+	messages := uniqueNames{}
+	for _, t := range p.Tables() {
+		body.WriteString("\n")
+		if t.Description != "" {
+			body.WriteString(docLines("//", t.Description))
+		}
+		fmt.Fprintf(&body, "message %s {\n", messages.add(tsName(t.Name)))
+
+		fields := uniqueNames{}
+		present := map[string]bool{}
+		for _, c := range t.Columns {
+			if present[c.Name] {
+				return fmt.Errorf("line %d: %s.%s is defined twice", c.Line, t.Name, c.Name)
+			}
+			present[c.Name] = true
+			number, err := lock.number(t.Name, c.Name)
+			if err != nil {
+				return err
+			}
+			typ := types.TypeOf(c)
+			if path, ok := protoImports[typ]; ok {
+				imports[path] = true
+			}
+			if c.Nullable() {
+				typ = "optional " + typ
+			}
+			if c.Description != "" {
+				body.WriteString(docLines("  //", c.Description))
+			}
+			fmt.Fprintf(&body, "  %s %s = %d;\n", typ, fields.add(protoField(c.Name)), number)
+		}
+
+		var numbers []int
+		var names []string
+		for column, number := range lock.Messages[t.Name] {
+			if present[column] {
+				continue
+			}
+			numbers = append(numbers, number)
+			if name := protoField(column); !fields[name] {
+				fields[name] = true
+				names = append(names, strconv.Quote(name))
+			}
+		}
+		if len(numbers) > 0 {
+			sort.Ints(numbers)
+			sort.Strings(names)
+			var reserved []string
+			for _, number := range numbers {
+				reserved = append(reserved, strconv.Itoa(number))
+			}
+			fmt.Fprintf(&body, "  reserved %s;\n", strings.Join(reserved, ", "))
+			if len(names) > 0 {
+				fmt.Fprintf(&body, "  reserved %s;\n", strings.Join(names, ", "))
+			}
+		}
+		body.WriteString("}\n")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by erd gen proto. DO NOT EDIT.\n\nsyntax = \"proto3\";\n")
+	if pkg != "" {
+		fmt.Fprintf(&buf, "\npackage %s;\n", pkg)
+	}
+	if len(imports) > 0 {
+		var paths []string
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		buf.WriteString("\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "import %s;\n", strconv.Quote(path))
+		}
+	}
+	body.WriteTo(&buf)
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// genProtoCommand is the gen proto command. The lock is saved only once the
+// .proto file is written, so that it never records numbers the file lacks.
+func genProtoCommand() cli.Command {
+	var lock ProtoLock
+	return genCommand("proto", "generate protobuf messages", ProtoTypes, []cli.Flag{
+		cli.StringFlag{
+			Name:  "package",
+			Usage: "package of the generated file.",
+		},
+		cli.StringFlag{
+			Name:  "lock",
+			Value: "erd.proto.lock",
+			Usage: "file recording the field numbers, created if missing.",
+		},
+	}, func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error {
+		var err error
+		if lock, err = LoadProtoLock(c.String("lock")); err != nil {
+			return err
+		}
+		return GenerateProto(p, c.String("package"), types, lock, wr)
+	}, func(c *cli.Context) error {
+		return lock.Save(c.String("lock"))
+	})
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/urfave/cli"
+)
+
+func TestGenerateProto(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		lock := ProtoLock{Messages: map[string]map[string]int{}}
+		var buf bytes.Buffer
+		So(GenerateProto(parseSample(t), "blog", ProtoTypes, lock, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.proto", buf.Bytes()))
+		So(lock.Messages["Post"], ShouldResemble, map[string]int{
+			"id": 1, "blog_id": 2, "category_id": 3, "title": 4, "text": 5,
+		})
+	})
+
+	Convey("Regenerating keeps the numbers and reserves dropped ones", t, func() {
+		lock := ProtoLock{Messages: map[string]map[string]int{}}
+		var buf bytes.Buffer
+		So(GenerateProto(Schema{{Name: "users", Columns: []Column{
+			{Name: "id"}, {Name: "name"}, {Name: "email"}, {Name: "userName"},
+		}}}, "", ProtoTypes, lock, &buf), ShouldBeNil)
+
+		buf.Reset()
+		So(GenerateProto(Schema{{Name: "users", Columns: []Column{
+			{Name: "email", Description: "moved up"},
+			{Name: "id"},
+			{Name: "created_at", Type: "timestamp NULL"},
+			{Name: "user_name"},
+		}}}, "", ProtoTypes, lock, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `// Code generated by erd gen proto. DO NOT EDIT.
+
+syntax = "proto3";
+
+import "google/protobuf/timestamp.proto";
+
+message Users {
+  // moved up
+  string email = 3;
+  int64 id = 1;
+  optional google.protobuf.Timestamp created_at = 5;
+  string user_name = 6;
+  reserved 2, 4;
+  reserved "name";
+}
+`)
+	})
+
+	Convey("Field numbers skip the range protobuf reserves", t, func() {
+		lock := ProtoLock{Messages: map[string]map[string]int{"t": {"a": 18999}}}
+		number, err := lock.number("t", "b")
+		So(err, ShouldBeNil)
+		So(number, ShouldEqual, 20000)
+	})
+
+	Convey("Lock files are saved and loaded", t, func() {
+		dir, err := ioutil.TempDir("", "erd")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "erd.proto.lock")
+
+		lock, err := LoadProtoLock(path)
+		So(err, ShouldBeNil)
+		So(lock.Messages, ShouldBeEmpty)
+		lock.Messages["users"] = map[string]int{"id": 1, "name": 2}
+		So(lock.Save(path), ShouldBeNil)
+
+		loaded, err := LoadProtoLock(path)
+		So(err, ShouldBeNil)
+		So(loaded, ShouldResemble, lock)
+
+		So(ioutil.WriteFile(path, []byte(`{"messages": {"users": {"id": 1, "name": 1}}}`), 0644), ShouldBeNil)
+		_, err = LoadProtoLock(path)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("The lock is saved only when the .proto file is written", t, func() {
+		exiter, errWriter := cli.OsExiter, cli.ErrWriter
+		defer func() { cli.OsExiter, cli.ErrWriter = exiter, errWriter }()
+		cli.OsExiter = func(int) {}
+		cli.ErrWriter = &bytes.Buffer{}
+
+		dir, err := ioutil.TempDir("", "erd")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		lock := filepath.Join(dir, "erd.proto.lock")
+		run := func(output string) error {
+			app := cli.NewApp()
+			app.Commands = []cli.Command{genProtoCommand()}
+			return app.Run([]string{"erd", "proto", "--lock", lock, "-o", output, "sample.erd"})
+		}
+
+		So(run(filepath.Join(dir, "missing", "erd.proto")), ShouldNotBeNil)
+		_, err = os.Stat(lock)
+		So(os.IsNotExist(err), ShouldBeTrue)
+
+		So(run(filepath.Join(dir, "erd.proto")), ShouldBeNil)
+		_, err = os.Stat(lock)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Invalid packages and repeated columns are errors", t, func() {
+		lock := ProtoLock{Messages: map[string]map[string]int{}}
+		var buf bytes.Buffer
+		So(GenerateProto(parseSample(t), "not a package", ProtoTypes, lock, &buf), ShouldNotBeNil)
+		So(GenerateProto(Schema{{Name: "t", Columns: []Column{{Name: "a"}, {Name: "a"}}}}, "", ProtoTypes, lock, &buf), ShouldNotBeNil)
+	})
+}
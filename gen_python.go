@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+var pythonIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pythonName returns the attribute name of a column.
+func pythonName(column string) string {
+	name := column
+	if !pythonIdentifier.MatchString(name) {
+		name = protoField(column)
+	}
+	if pythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// pythonString quotes s as a Python string literal. Go escapes are valid in
+// Python strings.
+func pythonString(s string) string {
+	return strconv.Quote(s)
+}
+
+// pythonDocstring returns a description as a docstring indented by indent.
+func pythonDocstring(indent, description string) string {
+	description = strings.Replace(strings.TrimSpace(description), `\`, `\\`, -1)
+	description = strings.Replace(description, `"""`, `\"\"\"`, -1)
+	if strings.HasSuffix(description, `"`) {
+		description = description[:len(description)-1] + `\"`
+	}
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		return indent + `"""` + description + `"""` + "\n"
+	}
+	var buf bytes.Buffer
+	buf.WriteString(indent + `"""` + lines[0] + "\n")
+	for _, line := range lines[1:] {
+		buf.WriteString(strings.TrimRight(indent+strings.TrimSpace(line), " ") + "\n")
+	}
+	buf.WriteString(indent + `"""` + "\n")
+	return buf.String()
+}
+
+// pythonImports collects the names imported from modules.
+type pythonImports map[string]map[string]bool
+
+// use returns how to write a type of a type map: a name in the default
+// module, like Integer, or a name in another module, like
+// sqlalchemy.dialects.postgresql.JSONB, optionally with arguments. The name
+// is imported from its module.
+func (imports pythonImports) use(defaultModule, typ string) string {
+	head, args := typ, ""
+	if i := strings.Index(typ, "("); i >= 0 {
+		head, args = typ[:i], typ[i:]
+	}
+	module, name := defaultModule, head
+	if dot := strings.LastIndex(head, "."); dot >= 0 {
+		module, name = head[:dot], head[dot+1:]
+	}
+	imports.add(module, name)
+	return name + args
+}
+
+func (imports pythonImports) add(module, name string) {
+	if imports[module] == nil {
+		imports[module] = map[string]bool{}
+	}
+	imports[module][name] = true
+}
+
+func (imports pythonImports) String() string {
+	var modules []string
+	for module := range imports {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	var buf bytes.Buffer
+	for _, module := range modules {
+		var names []string
+		for name := range imports[module] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		buf.WriteString("from " + module + " import " + strings.Join(names, ", ") + "\n")
+	}
+	return buf.String()
+}
+
+// typeArguments returns the parameters of a column type, like 10 and 2 of
+// decimal(10, 2).
+func typeArguments(c Column) []string {
+	t := strings.TrimSpace(c.BaseType())
+	open := strings.Index(t, "(")
+	if open < 0 || !strings.HasSuffix(t, ")") {
+		return nil
+	}
+	var args []string
+	for _, arg := range strings.Split(t[open+1:len(t)-1], ",") {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
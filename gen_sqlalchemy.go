@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SQLAlchemyTypes is the default mapping of column types to SQLAlchemy
+// types. Names without a module are imported from sqlalchemy, and others
+// from their module, like sqlalchemy.dialects.postgresql.JSONB.
+var SQLAlchemyTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "SmallInteger",
+		"int":              "Integer",
+		"serial":           "Integer",
+		"bigint":           "BigInteger",
+		"bigserial":        "BigInteger",
+		"boolean":          "Boolean",
+		"real":             "Float",
+		"float":            "Float",
+		"double":           "Float",
+		"double precision": "Float",
+		"numeric":          "Numeric",
+		"decimal":          "Numeric",
+		"text":             "Text",
+		"varchar":          "String",
+		"char":             "String",
+		"uuid":             "String(36)",
+		"date":             "Date",
+		"time":             "Time",
+		"datetime":         "DateTime",
+		"timestamp":        "DateTime",
+		"timestamptz":      "DateTime(timezone=True)",
+		"json":             "JSON",
+		"jsonb":            "JSON",
+		"bytea":            "LargeBinary",
+		"blob":             "LargeBinary",
+	},
+	Default: "Text",
+}
+
+// sqlalchemyParameterized are the SQLAlchemy types which take the parameters
+// of column types, like the length of varchar(128).
+var sqlalchemyParameterized = map[string]bool{"String": true, "Numeric": true}
+
+// GenerateSQLAlchemy writes a Python module with a SQLAlchemy declarative
+// model class per table. Columns which are not nullable are
+// nullable=False, relations become ForeignKey constraints, and descriptions
+// become docstrings of the classes and comments of the columns.
+func GenerateSQLAlchemy(p ParsedData, types TypeMap, wr io.Writer) error {
+	imports := pythonImports{}
+	imports.add("sqlalchemy", "Column")
+	imports.add("sqlalchemy.orm", "declarative_base")
+
+	var body bytes.Buffer
+	classes := uniqueNames{"Base": true}
+	for _, t := range p.Tables() {
+		fmt.Fprintf(&body, "\n\nclass %s(Base):\n", classes.add(tsName(t.Name)))
+		if t.Description != "" {
+			body.WriteString(pythonDocstring("    ", t.Description) + "\n")
+		}
+		fmt.Fprintf(&body, "    __tablename__ = %s\n\n", pythonString(t.Name))
+
+		attributes := uniqueNames{"metadata": true}
+		for _, c := range t.Columns {
+			name := attributes.add(pythonName(c.Name))
+			var args []string
+			if name != c.Name {
+				args = append(args, pythonString(c.Name))
+			}
+
+			typ := types.TypeOf(c)
+			if params := typeArguments(c); len(params) > 0 && sqlalchemyParameterized[typ] {
+				typ += "(" + strings.Join(params, ", ") + ")"
+			}
+			args = append(args, imports.use("sqlalchemy", typ))
+
+			if r := c.Relation; r != nil {
+				imports.add("sqlalchemy", "ForeignKey")
+				args = append(args, fmt.Sprintf("ForeignKey(%s)", pythonString(r.TableName+"."+r.ColumnName)))
+			}
+			if pk := t.PrimaryKey(); pk != nil && pk.Name == c.Name {
+				args = append(args, "primary_key=True")
+			} else if !c.Nullable() {
+				args = append(args, "nullable=False")
+			}
+			if c.Description != "" {
+				args = append(args, "comment="+pythonString(c.Description))
+			}
+			fmt.Fprintf(&body, "    %s = Column(%s)\n", name, strings.Join(args, ", "))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by erd gen sqlalchemy. DO NOT EDIT.\n\n")
+	buf.WriteString(imports.String())
+	buf.WriteString("\nBase = declarative_base()\n")
+	body.WriteTo(&buf)
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateSQLAlchemy(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(GenerateSQLAlchemy(parseSample(t), SQLAlchemyTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.sqlalchemy.py", buf.Bytes()))
+	})
+
+	Convey("Keywords, parameters, nullable columns and imported types", t, func() {
+		err, parser := parse(t, `
+orders : "Orders" {
+  id
+  from varchar(64) : sender
+  total decimal(10, 2) NULL
+  data jsonb
+  user_id -> users.id
+}
+
+users {
+  id
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		types := TypeMap{Types: map[string]string{
+			"bigint":  "BigInteger",
+			"varchar": "String",
+			"decimal": "Numeric",
+			"jsonb":   "sqlalchemy.dialects.postgresql.JSONB",
+		}, Default: "Text"}
+		var buf bytes.Buffer
+		So(GenerateSQLAlchemy(parser, types, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `# Code generated by erd gen sqlalchemy. DO NOT EDIT.
+
+from sqlalchemy import BigInteger, Column, ForeignKey, Numeric, String
+from sqlalchemy.dialects.postgresql import JSONB
+from sqlalchemy.orm import declarative_base
+
+Base = declarative_base()
+
+
+class Orders(Base):
+    """"Orders\""""
+
+    __tablename__ = "orders"
+
+    id = Column(BigInteger, primary_key=True)
+    from_ = Column("from", String(64), nullable=False, comment="sender")
+    total = Column(Numeric(10, 2))
+    data = Column(JSONB, nullable=False)
+    user_id = Column(BigInteger, ForeignKey("users.id"), nullable=False)
+
+
+class Users(Base):
+    __tablename__ = "users"
+
+    id = Column(BigInteger, primary_key=True)
+`)
+	})
+
+	Convey("Multi-line descriptions are docstrings", t, func() {
+		So(pythonDocstring("    ", "first\n  second"), ShouldEqual, "    \"\"\"first\n    second\n    \"\"\"\n")
+		So(pythonName("order-no"), ShouldEqual, "order_no")
+	})
+}
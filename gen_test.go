@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTypeMap(t *testing.T) {
+	m := TypeMap{
+		Types:   map[string]string{"double precision": "float64", "varchar": "string", "varchar(1)": "byte", "bigint": "int64"},
+		Default: "any",
+	}
+
+	Convey("Types are matched after normalization, with or without parameters", t, func() {
+		So(m.TypeOf(Column{Name: "x", Type: "DOUBLE  PRECISION"}), ShouldEqual, "float64")
+		So(m.TypeOf(Column{Name: "x", Type: "varchar(128)"}), ShouldEqual, "string")
+		So(m.TypeOf(Column{Name: "x", Type: "VARCHAR(1)"}), ShouldEqual, "byte")
+		So(m.TypeOf(Column{Name: "x", Type: "geometry"}), ShouldEqual, "any")
+	})
+
+	Convey("Untyped keys and relations are BIGINT", t, func() {
+		So(m.TypeOf(Column{Name: "id"}), ShouldEqual, "int64")
+		So(m.TypeOf(Column{Name: "user_id"}), ShouldEqual, "int64")
+		So(m.TypeOf(Column{Name: "owner", Relation: &Relation{TableName: "users", ColumnName: "id"}}), ShouldEqual, "int64")
+		So(m.TypeOf(Column{Name: "name"}), ShouldEqual, "any")
+	})
+
+	Convey("LoadTypeMap adds to and replaces the base types", t, func() {
+		dir, err := ioutil.TempDir("", "erd")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "types.json")
+		So(ioutil.WriteFile(path, []byte(`{"types": {"VARCHAR": "text", "uuid": "UUID"}}`), 0644), ShouldBeNil)
+
+		loaded, err := LoadTypeMap(path, m)
+		So(err, ShouldBeNil)
+		So(loaded.TypeOf(Column{Name: "x", Type: "varchar(128)"}), ShouldEqual, "text")
+		So(loaded.TypeOf(Column{Name: "x", Type: "uuid"}), ShouldEqual, "UUID")
+		So(loaded.TypeOf(Column{Name: "x", Type: "double precision"}), ShouldEqual, "float64")
+		So(loaded.Default, ShouldEqual, "any")
+		So(m.TypeOf(Column{Name: "x", Type: "uuid"}), ShouldEqual, "any")
+
+		So(ioutil.WriteFile(path, []byte(`{"types": []}`), 0644), ShouldBeNil)
+		_, err = LoadTypeMap(path, m)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestNames(t *testing.T) {
+	Convey("Names are split into words", t, func() {
+		So(nameWords("blog_post_id"), ShouldResemble, []string{"blog", "post", "id"})
+		So(nameWords("BlogPost"), ShouldResemble, []string{"Blog", "Post"})
+		So(nameWords("userID"), ShouldResemble, []string{"user", "ID"})
+		So(nameWords("HTTPServer"), ShouldResemble, []string{"HTTP", "Server"})
+		So(nameWords("order-items 2"), ShouldResemble, []string{"order", "items", "2"})
+		So(nameWords("__"), ShouldBeEmpty)
+	})
+
+	Convey("Repeated names are numbered", t, func() {
+		u := uniqueNames{}
+		So(u.add("A"), ShouldEqual, "A")
+		So(u.add("A"), ShouldEqual, "A2")
+		So(u.add("A"), ShouldEqual, "A3")
+	})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TypeScriptTypes is the default mapping of column types to TypeScript
+// types, as rows look once sent as JSON.
+var TypeScriptTypes = TypeMap{
+	Types: map[string]string{
+		"smallint":         "number",
+		"int":              "number",
+		"serial":           "number",
+		"bigint":           "number",
+		"bigserial":        "number",
+		"real":             "number",
+		"float":            "number",
+		"double":           "number",
+		"double precision": "number",
+		"numeric":          "number",
+		"decimal":          "number",
+		"boolean":          "boolean",
+		"json":             "unknown",
+		"jsonb":            "unknown",
+	},
+	Default: "string",
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsName makes a TypeScript type name of a table name, like BlogPost of
+// blog_post.
+func tsName(name string) string {
+	var sb strings.Builder
+	for _, word := range nameWords(name) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	s := sb.String()
+	if !tsIdentifier.MatchString(s) {
+		s = "T" + s
+	}
+	if !tsIdentifier.MatchString(s) {
+		s = "Table"
+	}
+	return s
+}
+
+// tsProperty returns a column name as a property name, quoted if needed.
+func tsProperty(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// tsDoc returns a description as a JSDoc comment indented by indent.
+func tsDoc(indent, description string) string {
+	description = strings.Replace(strings.TrimSpace(description), "*/", `*\/`, -1)
+	if !strings.Contains(description, "\n") {
+		return indent + "/** " + description + " */\n"
+	}
+	return indent + "/**\n" + docLines(indent+" *", description) + indent + " */\n"
+}
+
+// GenerateTypeScript writes a TypeScript interface per table, with a property
+// per column. Nullable columns are optional, columns with a relation have the
+// type of the property they reference, and descriptions become JSDoc
+// comments.
+func GenerateTypeScript(p ParsedData, types TypeMap, wr io.Writer) error {
+	names := map[string]string{}
+	unique := uniqueNames{}
+	for _, t := range p.Tables() {
+		if _, ok := names[t.Name]; !ok {
+			names[t.Name] = unique.add(tsName(t.Name))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by erd gen typescript. DO NOT EDIT.\n")
+	for _, t := range p.Tables() {
+		buf.WriteString("\n")
+		if t.Description != "" {
+			buf.WriteString(tsDoc("", t.Description))
+		}
+		fmt.Fprintf(&buf, "export interface %s {\n", names[t.Name])
+		for _, c := range t.Columns {
+			typ := types.TypeOf(c)
+			if r := c.Relation; r != nil {
+				if target, ok := names[r.TableName]; ok {
+					typ = fmt.Sprintf("%s[%s]", target, strconv.Quote(r.ColumnName))
+				}
+			}
+			property := tsProperty(c.Name) + ": "
+			if c.Nullable() {
+				property = tsProperty(c.Name) + "?: "
+				typ += " | null"
+			}
+			if c.Description != "" {
+				buf.WriteString(tsDoc("  ", c.Description))
+			}
+			buf.WriteString("  " + property + typ + ";\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	Convey("sample.erd matches the golden file", t, func() {
+		var buf bytes.Buffer
+		So(GenerateTypeScript(parseSample(t), TypeScriptTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, golden(t, "sample.ts", buf.Bytes()))
+	})
+
+	Convey("Nullable columns, references, names and comments", t, func() {
+		err, parser := parse(t, `
+blog_posts : Posts */ of a blog {
+  id
+  author_id BIGINT NULL -> users.id : who wrote it
+  editor_id ..> users.id
+  blog_id -> blogs.id
+  published_at timestamp NULL
+}
+
+users {
+  id
+}`)
+		So(err, ShouldBeNil)
+		ResolveTypes(parser.Tables())
+		var buf bytes.Buffer
+		So(GenerateTypeScript(parser, TypeScriptTypes, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, `// Code generated by erd gen typescript. DO NOT EDIT.
+
+/** Posts *\/ of a blog */
+export interface BlogPosts {
+  id: number;
+  /** who wrote it */
+  author_id?: Users["id"] | null;
+  editor_id: Users["id"];
+  blog_id: number;
+  published_at?: string | null;
+}
+
+export interface Users {
+  id: number;
+}
+`)
+	})
+
+	Convey("Multi-line descriptions are JSDoc blocks", t, func() {
+		So(tsDoc("  ", "first\nsecond"), ShouldEqual, "  /**\n   * first\n   * second\n   */\n")
+		So(tsName("2fa_codes"), ShouldEqual, "T2faCodes")
+		So(tsName("ユーザー"), ShouldEqual, "Table")
+		So(tsProperty("order-no"), ShouldEqual, `"order-no"`)
+	})
+}
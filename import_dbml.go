@@ -0,0 +1,558 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dbmlRef is a relation read from DBML, from the column of a table to the
+// column of another.
+type dbmlRef struct {
+	Line                   int
+	Table, Column          string
+	TargetTable, TargetCol string
+}
+
+// dbmlParser reads the parts of DBML the erd model has room for: tables with
+// their columns and notes, and relations. Projects, enums, indexes, table
+// groups and the other settings are skipped.
+type dbmlParser struct {
+	src     string
+	pos     int
+	tables  []Table
+	aliases map[string]string
+	refs    []dbmlRef
+	// newlines caches the offsets of the newlines of src for line
+	newlines []int
+}
+
+func (p *dbmlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("dbml: line %d: %s", p.line(), fmt.Sprintf(format, args...))
+}
+
+func (p *dbmlParser) line() int {
+	if p.newlines == nil {
+		p.newlines = []int{}
+		for i := 0; i < len(p.src); i++ {
+			if p.src[i] == '\n' {
+				p.newlines = append(p.newlines, i)
+			}
+		}
+	}
+	return sort.SearchInts(p.newlines, p.pos) + 1
+}
+
+func (p *dbmlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dbmlParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return r
+}
+
+// skipSpace skips spaces and comments, and newlines too if newlines is set.
+func (p *dbmlParser) skipSpace(newlines bool) {
+	for !p.eof() {
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], "//"):
+			if end := strings.IndexByte(p.src[p.pos:], '\n'); end >= 0 {
+				p.pos += end
+			} else {
+				p.pos = len(p.src)
+			}
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			if end := strings.Index(p.src[p.pos+2:], "*/"); end >= 0 {
+				p.pos += end + 4
+			} else {
+				p.pos = len(p.src)
+			}
+		case p.peek() == '\n' && newlines, p.peek() == ' ', p.peek() == '\t', p.peek() == '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *dbmlParser) consume(s string) bool {
+	p.skipSpace(false)
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *dbmlParser) expect(s string) error {
+	if !p.consume(s) {
+		return p.errorf("expected %q", s)
+	}
+	return nil
+}
+
+func isDBMLIdent(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// quoted reads a string quoted by the character at the current position,
+// with backslash escapes. Strings in triple single quotes may span lines and
+// have their indentation removed.
+func (p *dbmlParser) quoted() (string, error) {
+	if strings.HasPrefix(p.src[p.pos:], "'''") {
+		end := strings.Index(p.src[p.pos+3:], "'''")
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+		text := p.src[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		var lines []string
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		return strings.TrimSpace(strings.Join(lines, "\n")), nil
+	}
+
+	quote := p.src[p.pos]
+	var sb strings.Builder
+	for i := p.pos + 1; i < len(p.src); i++ {
+		switch c := p.src[i]; {
+		case c == quote:
+			p.pos = i + 1
+			return sb.String(), nil
+		case c == '\\' && i+1 < len(p.src):
+			i++
+			switch p.src[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(p.src[i])
+			}
+		case c == '\n' && quote != '`':
+			return "", p.errorf("unterminated string")
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// name reads an identifier or a double-quoted name.
+func (p *dbmlParser) name() (string, error) {
+	p.skipSpace(false)
+	if p.peek() == '"' {
+		return p.quoted()
+	}
+	start := p.pos
+	for !p.eof() && isDBMLIdent(p.peek()) {
+		_, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a name")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// qualifiedName reads names separated by dots, like schema.table.column.
+func (p *dbmlParser) qualifiedName() ([]string, error) {
+	var parts []string
+	for {
+		part, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if !strings.HasPrefix(p.src[p.pos:], ".") {
+			return parts, nil
+		}
+		p.pos++
+	}
+}
+
+// columnType reads the type of a column, like varchar(255), decimal(10, 2),
+// int[] or "double precision".
+func (p *dbmlParser) columnType() (string, error) {
+	p.skipSpace(false)
+	if p.peek() == '"' {
+		return p.quoted()
+	}
+	start, depth := p.pos, 0
+	for ; !p.eof(); p.pos++ {
+		c := p.src[p.pos]
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '[' && strings.HasPrefix(p.src[p.pos:], "[]"):
+			p.pos++
+		case c == '[' && depth == 0:
+			return p.src[start:p.pos], nil
+		case c == '\n' || c == '\r' || c == '}':
+			return p.src[start:p.pos], nil
+		case (c == ' ' || c == '\t') && depth == 0:
+			return p.src[start:p.pos], nil
+		}
+	}
+	if depth > 0 {
+		return "", p.errorf("unterminated column type")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// skipValue skips a setting value that is not read, like a default.
+func (p *dbmlParser) skipValue() error {
+	p.skipSpace(false)
+	if c := p.peek(); c == '\'' || c == '"' || c == '`' {
+		_, err := p.quoted()
+		return err
+	}
+	for !p.eof() && !strings.ContainsRune(",]\n", p.peek()) {
+		p.pos++
+	}
+	return nil
+}
+
+// skipBlock skips everything up to the brace closing the next block.
+func (p *dbmlParser) skipBlock() error {
+	depth := 0
+	for !p.eof() {
+		p.skipSpace(true)
+		switch c := p.peek(); c {
+		case '\'', '"', '`':
+			if _, err := p.quoted(); err != nil {
+				return err
+			}
+			continue
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+		if !p.eof() {
+			p.pos++
+		}
+	}
+	return p.errorf("unterminated block")
+}
+
+// relation reads the operator and target of a relation, like "> users.id",
+// and returns the ref from table.column it makes.
+func (p *dbmlParser) relation(line int, table, column string) (dbmlRef, error) {
+	p.skipSpace(false)
+	var op string
+	for _, o := range []string{"<>", ">", "<", "-"} {
+		if p.consume(o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return dbmlRef{}, p.errorf("expected a relation operator")
+	}
+	p.skipSpace(false)
+	target, err := p.qualifiedName()
+	if err != nil {
+		return dbmlRef{}, err
+	}
+	if len(target) < 2 {
+		return dbmlRef{}, p.errorf("expected table.column")
+	}
+	ref := dbmlRef{
+		Line:        line,
+		Table:       table,
+		Column:      column,
+		TargetTable: target[len(target)-2],
+		TargetCol:   target[len(target)-1],
+	}
+	switch op {
+	case "<>":
+		return dbmlRef{}, p.errorf("many-to-many relations are not supported")
+	case "<":
+		ref.Table, ref.Column, ref.TargetTable, ref.TargetCol = ref.TargetTable, ref.TargetCol, ref.Table, ref.Column
+	}
+	return ref, nil
+}
+
+// settings reads the settings in brackets after a table or column and
+// returns the note. Relations of columns are added to the refs.
+func (p *dbmlParser) settings(table, column string) (string, error) {
+	line := p.line()
+	note := ""
+	if !p.consume("[") {
+		return "", nil
+	}
+	for {
+		p.skipSpace(true)
+		var words []string
+		colon := false
+		for !colon && !strings.ContainsRune(",]", p.peek()) {
+			word, err := p.name()
+			if err != nil {
+				return "", err
+			}
+			words = append(words, strings.ToLower(word))
+			colon = p.consume(":")
+		}
+		if colon {
+			switch strings.Join(words, " ") {
+			case "note":
+				p.skipSpace(false)
+				if c := p.peek(); c != '\'' && c != '"' {
+					return "", p.errorf("expected a string")
+				}
+				var err error
+				if note, err = p.quoted(); err != nil {
+					return "", err
+				}
+			case "ref":
+				if column == "" {
+					return "", p.errorf("ref setting outside a column")
+				}
+				ref, err := p.relation(line, table, column)
+				if err != nil {
+					return "", err
+				}
+				p.refs = append(p.refs, ref)
+			default:
+				if err := p.skipValue(); err != nil {
+					return "", err
+				}
+			}
+		}
+		p.skipSpace(true)
+		if p.consume("]") {
+			return note, nil
+		}
+		if err := p.expect(","); err != nil {
+			return "", err
+		}
+	}
+}
+
+// note reads the note of a table after "Note", as "Note: '...'" or
+// "Note { '...' }".
+func (p *dbmlParser) note() (string, error) {
+	braces := !p.consume(":")
+	if braces {
+		if err := p.expect("{"); err != nil {
+			return "", err
+		}
+		p.skipSpace(true)
+	}
+	p.skipSpace(false)
+	if c := p.peek(); c != '\'' && c != '"' {
+		return "", p.errorf("expected a string")
+	}
+	note, err := p.quoted()
+	if err != nil {
+		return "", err
+	}
+	if braces {
+		p.skipSpace(true)
+		if err := p.expect("}"); err != nil {
+			return "", err
+		}
+	}
+	return note, nil
+}
+
+func (p *dbmlParser) table() error {
+	table := Table{Line: p.line()}
+	parts, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	table.Name = parts[len(parts)-1]
+	p.skipSpace(false)
+	if strings.HasPrefix(p.src[p.pos:], "as ") {
+		p.pos += len("as")
+		alias, err := p.name()
+		if err != nil {
+			return err
+		}
+		p.aliases[alias] = table.Name
+	}
+	if table.Description, err = p.settings(table.Name, ""); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return p.errorf("unterminated table %s", table.Name)
+		}
+		if p.consume("}") {
+			break
+		}
+		line := p.line()
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		p.skipSpace(false)
+		next := p.peek()
+		switch {
+		case strings.EqualFold(name, "note") && (next == ':' || next == '{'):
+			if table.Description, err = p.note(); err != nil {
+				return err
+			}
+			continue
+		case strings.EqualFold(name, "indexes") && next == '{':
+			if err := p.skipBlock(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		column := Column{Name: name, Line: line}
+		if column.Type, err = p.columnType(); err != nil {
+			return err
+		}
+		if column.Type == "" {
+			return p.errorf("expected the type of column %s", name)
+		}
+		if column.Type == dbmlUntyped {
+			column.Type = ""
+		}
+		if column.Description, err = p.settings(table.Name, name); err != nil {
+			return err
+		}
+		p.skipSpace(false)
+		if c := p.peek(); c != '\n' && c != '}' && !p.eof() {
+			return p.errorf("unexpected %q after column %s", c, name)
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	p.tables = append(p.tables, table)
+	return nil
+}
+
+// ref reads a Ref after "Ref": an optional name, then one relation after
+// a colon or several in braces.
+func (p *dbmlParser) ref() error {
+	p.skipSpace(false)
+	if c := p.peek(); c != ':' && c != '{' {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+	}
+	one := func() error {
+		p.skipSpace(false)
+		line := p.line()
+		source, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		if len(source) < 2 {
+			return p.errorf("expected table.column")
+		}
+		ref, err := p.relation(line, source[len(source)-2], source[len(source)-1])
+		if err != nil {
+			return err
+		}
+		p.refs = append(p.refs, ref)
+		_, err = p.settings("", "")
+		return err
+	}
+
+	if p.consume(":") {
+		return one()
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		p.skipSpace(true)
+		if p.consume("}") {
+			return nil
+		}
+		if p.eof() {
+			return p.errorf("unterminated Ref")
+		}
+		if err := one(); err != nil {
+			return err
+		}
+	}
+}
+
+// resolve sets the relations of the columns from the refs.
+func (p *dbmlParser) resolve() error {
+	tableName := func(name string) string {
+		if table, ok := p.aliases[name]; ok {
+			return table
+		}
+		return name
+	}
+	for _, ref := range p.refs {
+		found := false
+		for i := range p.tables {
+			if p.tables[i].Name != tableName(ref.Table) {
+				continue
+			}
+			for j := range p.tables[i].Columns {
+				if c := &p.tables[i].Columns[j]; c.Name == ref.Column {
+					c.Relation = &Relation{
+						LineType:   NormalLine,
+						TableName:  tableName(ref.TargetTable),
+						ColumnName: ref.TargetCol,
+					}
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("dbml: line %d: unknown column %s.%s", ref.Line, ref.Table, ref.Column)
+		}
+	}
+	return nil
+}
+
+// ParseDBML reads the tables of a DBML file, as written by dbdiagram.io or
+// ExportDBML. Columns of the type "any" are read as untyped.
+func ParseDBML(text string) (Schema, error) {
+	p := &dbmlParser{src: text, aliases: map[string]string{}}
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			break
+		}
+		keyword, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(keyword) {
+		case "table":
+			err = p.table()
+		case "ref":
+			err = p.ref()
+		case "project", "enum", "tablegroup", "tablepartial", "note", "records":
+			err = p.skipBlock()
+		default:
+			err = p.errorf("unexpected %q", keyword)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.resolve(); err != nil {
+		return nil, err
+	}
+	return Schema(p.tables), nil
+}
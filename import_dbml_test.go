@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseDBML(t *testing.T) {
+	Convey("Tables, columns, notes and relations are read", t, func() {
+		schema, err := ParseDBML(`
+Project blog {
+  database_type: 'PostgreSQL'
+  Note: 'The blog'
+}
+
+// users of the blog
+Table public.users as U [headercolor: #3498DB, note: 'Registered users'] {
+  id integer [pk, increment]
+  name "character varying" [not null, note: 'Full name']
+  amount decimal(10, 2) [default: 0]
+  tags text[]
+  created_at timestamp [default: ` + "`now()`" + `]
+
+  indexes {
+    (name, created_at) [unique]
+  }
+}
+
+Table posts {
+  id integer [primary key]
+  user_id integer [ref: > U.id]
+  body text /* the post */
+  Note {
+    '''
+    Posts written
+    by users
+    '''
+  }
+}
+
+Table comments {
+  id integer
+  post_id integer
+  author_id integer
+}
+
+Enum status {
+  draft [note: 'not published']
+  published
+}
+
+Ref: comments.post_id > posts.id
+Ref fk_author {
+  users.id < comments.author_id [delete: cascade]
+}
+`)
+		So(err, ShouldBeNil)
+		So(schema, ShouldResemble, Schema{
+			{Name: "users", Description: "Registered users", Line: 8, Columns: []Column{
+				{Name: "id", Type: "integer", Line: 9},
+				{Name: "name", Type: "character varying", Description: "Full name", Line: 10},
+				{Name: "amount", Type: "decimal(10, 2)", Line: 11},
+				{Name: "tags", Type: "text[]", Line: 12},
+				{Name: "created_at", Type: "timestamp", Line: 13},
+			}},
+			{Name: "posts", Description: "Posts written\nby users", Line: 20, Columns: []Column{
+				{Name: "id", Type: "integer", Line: 21},
+				{Name: "user_id", Type: "integer", Line: 22, Relation: &Relation{LineType: NormalLine, TableName: "users", ColumnName: "id"}},
+				{Name: "body", Type: "text", Line: 23},
+			}},
+			{Name: "comments", Line: 32, Columns: []Column{
+				{Name: "id", Type: "integer", Line: 33},
+				{Name: "post_id", Type: "integer", Line: 34, Relation: &Relation{LineType: NormalLine, TableName: "posts", ColumnName: "id"}},
+				{Name: "author_id", Type: "integer", Line: 35, Relation: &Relation{LineType: NormalLine, TableName: "users", ColumnName: "id"}},
+			}},
+		})
+	})
+
+	Convey("The type any is no type", t, func() {
+		schema, err := ParseDBML("Table a {\n  id any\n}\n")
+		So(err, ShouldBeNil)
+		So(schema[0].Columns[0].Type, ShouldEqual, "")
+	})
+
+	Convey("Unsupported or broken DBML is an error with its line", t, func() {
+		_, err := ParseDBML("Table a {\n  id int\n}\nTable b {\n  id int\n}\nRef: a.id <> b.id\n")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "dbml: line 7: many-to-many relations are not supported")
+
+		_, err = ParseDBML("Table a {\n  id int\n}\nRef: a.missing > a.id\n")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "dbml: line 4: unknown column a.missing")
+
+		_, err = ParseDBML("Table a {\n  id\n}\n")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "dbml: line 2: expected the type of column id")
+
+		_, err = ParseDBML("Table a {\n  id int\n")
+		So(err, ShouldNotBeNil)
+
+		_, err = ParseDBML("View a {}")
+		So(err, ShouldNotBeNil)
+
+		// found by FuzzParseDBML: a bracket inside parentheses at the end
+		// used to scan past the input
+		_, err = ParseDBML("Table a{w([")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "dbml: line 1: unterminated column type")
+	})
+
+	Convey("Array types keep their brackets", t, func() {
+		schema, err := ParseDBML("Table a {\n  b int[] [note: 'x']\n  c decimal(1, [2])\n}\n")
+		So(err, ShouldBeNil)
+		So(schema[0].Columns[0].Type, ShouldEqual, "int[]")
+		So(schema[0].Columns[0].Description, ShouldEqual, "x")
+		So(schema[0].Columns[1].Type, ShouldEqual, "decimal(1, [2])")
+	})
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// prismaSQLTypes are the column types of the Prisma scalar types of fields
+// without a native @db type.
+var prismaSQLTypes = map[string]string{
+	"String":   "TEXT",
+	"Boolean":  "BOOLEAN",
+	"Int":      "INT",
+	"BigInt":   "BIGINT",
+	"Float":    "DOUBLE PRECISION",
+	"Decimal":  "DECIMAL",
+	"DateTime": "TIMESTAMP",
+	"Json":     "JSON",
+	"Bytes":    "BYTEA",
+}
+
+var (
+	prismaBlock    = regexp.MustCompile(`^(\w+)\s+(\w+)\s*\{$`)
+	prismaString   = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+	prismaFields   = regexp.MustCompile(`\bfields\s*:\s*\[([^\]]*)\]`)
+	prismaRefs     = regexp.MustCompile(`\breferences\s*:\s*\[([^\]]*)\]`)
+	prismaNative   = regexp.MustCompile(`^@db\.(\w+(?:\(.*\))?)$`)
+	prismaTypeName = regexp.MustCompile(`^\w+$`)
+)
+
+// prismaTokens splits a line at spaces outside of parentheses, brackets and
+// strings, and drops a // comment ending it.
+func prismaTokens(line string) []string {
+	var tokens []string
+	var token strings.Builder
+	depth, quoted := 0, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(line):
+			token.WriteByte(c)
+			i++
+			c = line[i]
+		case quoted && c == '"':
+			quoted = false
+		case quoted:
+		case c == '"':
+			quoted = true
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case strings.HasPrefix(line[i:], "//") && depth == 0:
+			i = len(line)
+			continue
+		case (c == ' ' || c == '\t') && depth == 0:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			continue
+		}
+		token.WriteByte(c)
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// prismaMapped returns the name given by a @map or @@map attribute, if any.
+func prismaMapped(attributes []string, attribute string) (string, bool, error) {
+	for _, a := range attributes {
+		if strings.HasPrefix(a, attribute+"(") {
+			m := prismaString.FindStringSubmatch(a)
+			if m == nil {
+				return "", false, fmt.Errorf("expected a name in %s", a)
+			}
+			name, err := strconv.Unquote(`"` + m[1] + `"`)
+			return name, true, err
+		}
+	}
+	return "", false, nil
+}
+
+func prismaList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// prismaField is a field of a model read from schema.prisma.
+type prismaField struct {
+	Line       int
+	Name       string
+	Type       string
+	Attributes []string
+	Doc        string
+}
+
+type prismaSchemaModel struct {
+	Line   int
+	Name   string
+	Doc    string
+	Fields []prismaField
+	Map    []string
+}
+
+// ParsePrisma reads the models of a schema.prisma file as tables. Scalar
+// fields become columns, named by their @map, and typed by their native
+// @db type or else after their Prisma type, with NULL for optional fields.
+// Relation fields with fields and references give the relations of the
+// columns. Other blocks, like datasources and enums, are skipped.
+func ParsePrisma(text string) (Schema, error) {
+	var models []*prismaSchemaModel
+	var model *prismaSchemaModel
+	var doc []string
+	skipping := false
+	errorf := func(line int, format string, args ...interface{}) error {
+		return fmt.Errorf("prisma: line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		number := i + 1
+		line = strings.TrimSpace(line)
+		// the line without its comment, to match block lines
+		bare := strings.Join(prismaTokens(line), " ")
+		switch {
+		case strings.HasPrefix(line, "///"):
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(line, "///")))
+			continue
+		case skipping:
+			if bare == "}" {
+				skipping = false
+			}
+		case model == nil:
+			if bare == "" {
+				break
+			}
+			m := prismaBlock.FindStringSubmatch(bare)
+			if m == nil {
+				return nil, errorf(number, "unexpected %q", line)
+			}
+			if m[1] == "model" {
+				model = &prismaSchemaModel{Line: number, Name: m[2], Doc: strings.Join(doc, "\n")}
+			} else {
+				skipping = true
+			}
+		case bare == "}":
+			models = append(models, model)
+			model = nil
+		default:
+			tokens := prismaTokens(line)
+			switch {
+			case len(tokens) == 0:
+			case strings.HasPrefix(tokens[0], "@@"):
+				model.Map = append(model.Map, tokens...)
+			case len(tokens) < 2:
+				return nil, errorf(number, "expected the type of field %s", tokens[0])
+			default:
+				model.Fields = append(model.Fields, prismaField{
+					Line:       number,
+					Name:       tokens[0],
+					Type:       tokens[1],
+					Attributes: tokens[2:],
+					Doc:        strings.Join(doc, "\n"),
+				})
+			}
+		}
+		doc = nil
+	}
+	if model != nil || skipping {
+		return nil, fmt.Errorf("prisma: unterminated block")
+	}
+
+	// the tables of the models and the columns of their fields
+	tables := map[string]string{}
+	columns := map[string]map[string]string{}
+	for _, m := range models {
+		table, ok, err := prismaMapped(m.Map, "@@map")
+		if err != nil {
+			return nil, errorf(m.Line, "%v", err)
+		}
+		if !ok {
+			table = m.Name
+		}
+		tables[m.Name] = table
+		columns[m.Name] = map[string]string{}
+		for _, f := range m.Fields {
+			column, ok, err := prismaMapped(f.Attributes, "@map")
+			if err != nil {
+				return nil, errorf(f.Line, "%v", err)
+			}
+			if !ok {
+				column = f.Name
+			}
+			columns[m.Name][f.Name] = column
+		}
+	}
+
+	var schema Schema
+	for _, m := range models {
+		table := Table{Name: tables[m.Name], Description: m.Doc, Line: m.Line}
+		relations := map[string]*Relation{}
+		for _, f := range m.Fields {
+			base := strings.TrimRight(f.Type, "?[]")
+			modifier := f.Type[len(base):]
+			if !prismaTypeName.MatchString(base) && !strings.HasPrefix(base, "Unsupported(") ||
+				modifier != "" && modifier != "?" && modifier != "[]" {
+				return nil, errorf(f.Line, "unknown type %s", f.Type)
+			}
+			if _, ok := tables[base]; !ok {
+				column := Column{Name: columns[m.Name][f.Name], Description: f.Doc, Line: f.Line}
+				column.Type = prismaColumnType(base, f.Attributes)
+				switch modifier {
+				case "[]":
+					column.Type += "[]"
+				case "?":
+					column.Type += " NULL"
+				}
+				table.Columns = append(table.Columns, column)
+				continue
+			}
+
+			// a relation field
+			for _, a := range f.Attributes {
+				if !strings.HasPrefix(a, "@relation(") {
+					continue
+				}
+				fields, refs := prismaFields.FindStringSubmatch(a), prismaRefs.FindStringSubmatch(a)
+				if fields == nil || refs == nil {
+					continue
+				}
+				from, to := prismaList(fields[1]), prismaList(refs[1])
+				if len(from) != len(to) {
+					return nil, errorf(f.Line, "fields and references of %s differ in length", f.Name)
+				}
+				for k := range from {
+					column, ok := columns[m.Name][from[k]]
+					if !ok {
+						return nil, errorf(f.Line, "unknown field %s", from[k])
+					}
+					target, ok := columns[base][to[k]]
+					if !ok {
+						return nil, errorf(f.Line, "unknown field %s.%s", base, to[k])
+					}
+					relations[column] = &Relation{LineType: NormalLine, TableName: tables[base], ColumnName: target}
+				}
+			}
+		}
+		for i, c := range table.Columns {
+			table.Columns[i].Relation = relations[c.Name]
+		}
+		schema = append(schema, table)
+	}
+	return schema, nil
+}
+
+// prismaColumnType returns the column type of a scalar field: its native
+// type, or the one of its Prisma type, or the name of its enum or of an
+// unsupported type.
+func prismaColumnType(typ string, attributes []string) string {
+	for _, a := range attributes {
+		if m := prismaNative.FindStringSubmatch(a); m != nil {
+			return m[1]
+		}
+	}
+	if sqlType, ok := prismaSQLTypes[typ]; ok {
+		return sqlType
+	}
+	if m := prismaString.FindStringSubmatch(typ); m != nil && strings.HasPrefix(typ, "Unsupported(") {
+		return m[1]
+	}
+	return typ
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParsePrisma(t *testing.T) {
+	Convey("Models, fields and relations are read", t, func() {
+		schema, err := ParsePrisma(`datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+
+generator client {
+  provider = "prisma-client-js"
+}
+
+enum Role {
+  USER
+  ADMIN
+}
+
+/// Registered users
+model User {
+  id        Int      @id @default(autoincrement())
+  /// Shown on the profile
+  email     String   @unique @db.VarChar(128)
+  role      Role     @default(USER)
+  bio       String?  // optional
+  tags      String[]
+  location  Unsupported("point")?
+  posts     Post[]
+
+  @@map("users")
+}
+
+model Post {
+  id        Int      @id
+  authorId  Int      @map("author_id")
+  editorId  Int?     @map("editor_id")
+  createdAt DateTime @default(now()) @map("created_at")
+  author    User     @relation("written", fields: [authorId], references: [id], onDelete: Cascade)
+  editor    User?    @relation(fields: [editorId], references: [id])
+
+  @@map("posts")
+}
+`)
+		So(err, ShouldBeNil)
+		So(schema, ShouldResemble, Schema{
+			{Name: "users", Description: "Registered users", Line: 16, Columns: []Column{
+				{Name: "id", Type: "INT", Line: 17},
+				{Name: "email", Type: "VarChar(128)", Description: "Shown on the profile", Line: 19},
+				{Name: "role", Type: "Role", Line: 20},
+				{Name: "bio", Type: "TEXT NULL", Line: 21},
+				{Name: "tags", Type: "TEXT[]", Line: 22},
+				{Name: "location", Type: "point NULL", Line: 23},
+			}},
+			{Name: "posts", Line: 29, Columns: []Column{
+				{Name: "id", Type: "INT", Line: 30},
+				{Name: "author_id", Type: "INT", Line: 31, Relation: &Relation{LineType: NormalLine, TableName: "users", ColumnName: "id"}},
+				{Name: "editor_id", Type: "INT NULL", Line: 32, Relation: &Relation{LineType: NormalLine, TableName: "users", ColumnName: "id"}},
+				{Name: "created_at", Type: "TIMESTAMP", Line: 33},
+			}},
+		})
+	})
+
+	Convey("ParsePrisma reads back the tables ExportPrisma writes", t, func() {
+		p := parseSample(t)
+		ResolveTypes(p.Tables())
+		var buf bytes.Buffer
+		So(ExportPrisma(p, &buf), ShouldBeNil)
+		schema, err := ParsePrisma(buf.String())
+		So(err, ShouldBeNil)
+
+		// the types are the native types, or else the ones of the Prisma types
+		want := withoutLines(p.Tables())
+		for _, table := range want {
+			for i, c := range table.Columns {
+				table.Columns[i].Type = prismaSQLTypes[PrismaTypes.TypeOf(c)]
+				if native, ok := prismaNativeType(c); ok {
+					table.Columns[i].Type = strings.TrimPrefix(native, "@db.")
+				}
+				if c.Relation != nil {
+					r := *c.Relation
+					r.LineType = NormalLine
+					table.Columns[i].Relation = &r
+				}
+			}
+		}
+		So(withoutLines(schema), ShouldResemble, want)
+	})
+
+	Convey("Types with arguments survive a round trip", t, func() {
+		err, parser := parse(t, `
+users {
+  id
+  name varchar(128)
+  country char(2) NULL
+  balance decimal(10, 2)
+  visits int(11)
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportPrisma(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "name    String  @db.VarChar(128)")
+		So(buf.String(), ShouldContainSubstring, "visits  Int\n")
+		schema, err := ParsePrisma(buf.String())
+		So(err, ShouldBeNil)
+
+		var types []string
+		for _, c := range schema[0].Columns {
+			types = append(types, normalizeType(c.Type))
+		}
+		So(types, ShouldResemble, []string{"BIGINT", "VARCHAR(128)", "CHAR(2)NULL", "DECIMAL(10,2)", "INT"})
+	})
+
+	Convey("Broken schemas are errors with their line", t, func() {
+		_, err := ParsePrisma("model User {\n  id\n}\n")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "prisma: line 2: expected the type of field id")
+
+		_, err = ParsePrisma("model User {\n  id Int\n")
+		So(err, ShouldNotBeNil)
+
+		_, err = ParsePrisma("model User {\n  id Int\n  post Post @relation(fields: [postId], references: [id])\n}\nmodel Post {\n  id Int\n}\n")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "prisma: line 3: unknown field postId")
+
+		_, err = ParsePrisma("table User {\n}\n")
+		So(err, ShouldBeNil)
+		_, err = ParsePrisma("User {\n}\n")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"sort"
+)
+
+const (
+	layoutMargin       = 20.0
+	layoutLayerGap     = 100.0
+	layoutBoxGap       = 30.0
+	layoutPadding      = 8.0
+	layoutRowHeight    = 20.0
+	layoutHeaderHeight = 24.0
+	layoutCharWidth    = 7.0
+	layoutMinWidth     = 80.0
+)
+
+func textWidth(s string) float64 {
+	return float64(textCells(s)) * layoutCharWidth
+}
+
+// layoutBox is the place of a table in a diagram.
+type layoutBox struct {
+	Table         Table
+	Layer         int
+	X, Y          float64
+	Width, Height float64
+}
+
+func (b layoutBox) headerHeight() float64 {
+	if b.Table.Description != "" {
+		return layoutHeaderHeight + layoutRowHeight
+	}
+	return layoutHeaderHeight
+}
+
+// rowY returns the vertical center of the row of the named column, or of the
+// header if there is no such column.
+func (b layoutBox) rowY(column string) float64 {
+	for i, c := range b.Table.Columns {
+		if c.Name == column {
+			return b.Y + b.headerHeight() + layoutRowHeight*(float64(i)+0.5)
+		}
+	}
+	return b.Y + layoutHeaderHeight/2
+}
+
+// columnLabel is the text of the row of a column.
+func columnLabel(c Column) string {
+	label := c.Name
+	if c.Type != "" {
+		label += " " + c.Type
+	}
+	if c.Description != "" {
+		label += " " + c.Description
+	}
+	return label
+}
+
+func boxSize(t Table) (float64, float64) {
+	width := textWidth(t.Name)
+	if w := textWidth(t.Description); w > width {
+		width = w
+	}
+	for _, c := range t.Columns {
+		if w := textWidth(columnLabel(c)); w > width {
+			width = w
+		}
+	}
+	width += 2 * layoutPadding
+	if width < layoutMinWidth {
+		width = layoutMinWidth
+	}
+	b := layoutBox{Table: t}
+	return width, b.headerHeight() + layoutRowHeight*float64(len(t.Columns))
+}
+
+// diagramLayout places tables in layers from left to right, so that tables
+// are left of the tables they reference, like dot does with rankdir=LR.
+type diagramLayout struct {
+	Boxes         []layoutBox
+	Width, Height float64
+}
+
+func (l diagramLayout) box(name string) (layoutBox, bool) {
+	for _, b := range l.Boxes {
+		if b.Table.Name == name {
+			return b, true
+		}
+	}
+	return layoutBox{}, false
+}
+
+// layoutTables computes a deterministic layout of the tables. The layer of a
+// table is the length of the longest chain of relations leading to it, with
+// cycles broken where a depth-first search in source order closes them. In
+// a layer, tables are sorted by the average position of the tables
+// referencing them.
+func layoutTables(tables []Table) diagramLayout {
+	index := map[string]int{}
+	for i, t := range tables {
+		if _, ok := index[t.Name]; !ok {
+			index[t.Name] = i
+		}
+	}
+	targets := make([][]int, len(tables))
+	for i, t := range tables {
+		for _, c := range t.ColumnsWithRelation() {
+			if j, ok := index[c.Relation.TableName]; ok && j != i {
+				targets[i] = append(targets[i], j)
+			}
+		}
+	}
+
+	// drop the edges closing cycles and sort the rest topologically
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(tables))
+	dag := make([][]int, len(tables))
+	var order []int
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		for _, j := range targets[i] {
+			if state[j] == visiting {
+				continue
+			}
+			dag[i] = append(dag[i], j)
+			if state[j] == unvisited {
+				visit(j)
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+	}
+	for i := range tables {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	layers := make([]int, len(tables))
+	numLayers := 0
+	for k := len(order) - 1; k >= 0; k-- {
+		i := order[k]
+		for _, j := range dag[i] {
+			if layers[i]+1 > layers[j] {
+				layers[j] = layers[i] + 1
+			}
+		}
+	}
+	members := map[int][]int{}
+	for i, layer := range layers {
+		members[layer] = append(members[layer], i)
+		if layer+1 > numLayers {
+			numLayers = layer + 1
+		}
+	}
+
+	position := make([]float64, len(tables))
+	for layer := 0; layer < numLayers; layer++ {
+		nodes := members[layer]
+		key := make(map[int]float64, len(nodes))
+		for k, i := range nodes {
+			key[i] = float64(k)
+			sum, n := 0.0, 0
+			for from, tos := range dag {
+				for _, to := range tos {
+					if to == i && layers[from] < layer {
+						sum += position[from]
+						n++
+					}
+				}
+			}
+			if n > 0 {
+				key[i] = sum / float64(n)
+			}
+		}
+		sort.SliceStable(nodes, func(a, b int) bool {
+			return key[nodes[a]] < key[nodes[b]]
+		})
+		for k, i := range nodes {
+			position[i] = float64(k)
+		}
+	}
+
+	var l diagramLayout
+	x := layoutMargin
+	for layer := 0; layer < numLayers; layer++ {
+		y := layoutMargin
+		layerWidth := 0.0
+		for _, i := range members[layer] {
+			width, height := boxSize(tables[i])
+			l.Boxes = append(l.Boxes, layoutBox{
+				Table:  tables[i],
+				Layer:  layer,
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+			y += height + layoutBoxGap
+			if width > layerWidth {
+				layerWidth = width
+			}
+		}
+		if h := y - layoutBoxGap + layoutMargin; h > l.Height {
+			l.Height = h
+		}
+		x += layerWidth + layoutLayerGap
+	}
+	l.Width = x - layoutLayerGap + layoutMargin
+	if len(tables) == 0 {
+		l.Width, l.Height = 2*layoutMargin, 2*layoutMargin
+	}
+	return l
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Severity int
+
+const (
+	SeverityOff Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "off"
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "off":
+		return SeverityOff, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	}
+	return SeverityOff, fmt.Errorf("unknown severity %q", s)
+}
+
+type LintIssue struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line"`
+	Table    string   `json:"table"`
+	Column   string   `json:"column,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// LintContext collects the issues a rule reports for a single table.
+type LintContext struct {
+	rule   LintRule
+	table  Table
+	issues []LintIssue
+}
+
+func (c *LintContext) ReportTable(format string, args ...interface{}) {
+	c.issues = append(c.issues, LintIssue{
+		Rule:    c.rule.Name,
+		Line:    c.table.Line,
+		Table:   c.table.Name,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *LintContext) ReportColumn(column Column, format string, args ...interface{}) {
+	c.issues = append(c.issues, LintIssue{
+		Rule:    c.rule.Name,
+		Line:    column.Line,
+		Table:   c.table.Name,
+		Column:  column.Name,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+type LintRule struct {
+	Name        string
+	Description string
+	Severity    Severity
+	Check       func(c *LintContext, t Table)
+}
+
+var lintRules []LintRule
+
+// RegisterLintRule adds a rule to the registry used by Lint.
+func RegisterLintRule(rule LintRule) {
+	lintRules = append(lintRules, rule)
+}
+
+func LintRules() []LintRule {
+	return lintRules
+}
+
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+func isSnakeCase(name string) bool {
+	return snakeCasePattern.MatchString(name)
+}
+
+var irregularPlurals = map[string]bool{
+	"people":   true,
+	"children": true,
+	"men":      true,
+	"women":    true,
+	"data":     true,
+	"media":    true,
+}
+
+// isPlural guesses whether the last word of name is an English plural.
+func isPlural(name string) bool {
+	words := strings.Split(name, "_")
+	word := strings.ToLower(words[len(words)-1])
+	if irregularPlurals[word] {
+		return true
+	}
+	for _, suffix := range []string{"ss", "us", "is"} {
+		if strings.HasSuffix(word, suffix) {
+			return false
+		}
+	}
+	return strings.HasSuffix(word, "s")
+}
+
+func init() {
+	RegisterLintRule(LintRule{
+		Name:        "snake-case",
+		Description: "table and column names must be snake_case",
+		Severity:    SeverityWarning,
+		Check: func(c *LintContext, t Table) {
+			if !isSnakeCase(t.Name) {
+				c.ReportTable("table name %q is not snake_case", t.Name)
+			}
+			for _, column := range t.Columns {
+				if !isSnakeCase(column.Name) {
+					c.ReportColumn(column, "column name %q is not snake_case", column.Name)
+				}
+			}
+		},
+	})
+	RegisterLintRule(LintRule{
+		Name:        "fk-suffix",
+		Description: "columns with a relation must end in _id",
+		Severity:    SeverityWarning,
+		Check: func(c *LintContext, t Table) {
+			for _, column := range t.ColumnsWithRelation() {
+				if !strings.HasSuffix(column.Name, "_id") {
+					c.ReportColumn(column, "foreign key column %q does not end in _id", column.Name)
+				}
+			}
+		},
+	})
+	RegisterLintRule(LintRule{
+		Name:        "table-description",
+		Description: "every table must have a description",
+		Severity:    SeverityWarning,
+		Check: func(c *LintContext, t Table) {
+			if t.Description == "" {
+				c.ReportTable("table %q has no description", t.Name)
+			}
+		},
+	})
+	RegisterLintRule(LintRule{
+		Name:        "primary-key",
+		Description: "every table must have an id column",
+		Severity:    SeverityError,
+		Check: func(c *LintContext, t Table) {
+			if t.PrimaryKey() == nil {
+				c.ReportTable("table %q has no primary key", t.Name)
+			}
+		},
+	})
+	RegisterLintRule(LintRule{
+		Name:        "singular-table-name",
+		Description: "table names must be singular",
+		Severity:    SeverityOff,
+		Check: func(c *LintContext, t Table) {
+			if isPlural(t.Name) {
+				c.ReportTable("table name %q is not singular", t.Name)
+			}
+		},
+	})
+	RegisterLintRule(LintRule{
+		Name:        "plural-table-name",
+		Description: "table names must be plural",
+		Severity:    SeverityOff,
+		Check: func(c *LintContext, t Table) {
+			if !isPlural(t.Name) {
+				c.ReportTable("table name %q is not plural", t.Name)
+			}
+		},
+	})
+}
+
+// LintConfig overrides the default severity of rules by name.
+type LintConfig struct {
+	Rules map[string]string `json:"rules"`
+}
+
+func LoadLintConfig(path string) (LintConfig, error) {
+	var config LintConfig
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("%s: %v", path, err)
+	}
+	for name, severity := range config.Rules {
+		if findLintRule(name) == nil {
+			return config, fmt.Errorf("%s: unknown lint rule %q", path, name)
+		}
+		if _, err := ParseSeverity(severity); err != nil {
+			return config, fmt.Errorf("%s: rule %q: %v", path, name, err)
+		}
+	}
+	return config, nil
+}
+
+func (c LintConfig) severity(rule LintRule) Severity {
+	if s, ok := c.Rules[rule.Name]; ok {
+		severity, err := ParseSeverity(s)
+		if err == nil {
+			return severity
+		}
+	}
+	return rule.Severity
+}
+
+func findLintRule(name string) *LintRule {
+	for i := range lintRules {
+		if lintRules[i].Name == name {
+			return &lintRules[i]
+		}
+	}
+	return nil
+}
+
+const lintIgnoreDirective = "lint:ignore"
+
+// lintSuppressions maps a source line to the rules ignored on it. A
+// "# lint:ignore rule..." comment applies to the table or column on the same
+// line, or else to the first one after it. An empty rule list ignores all rules.
+func lintSuppressions(tables []Table, comments []Comment) map[int][]string {
+	var lines []int
+	for _, t := range tables {
+		lines = append(lines, t.Line)
+		for _, c := range t.Columns {
+			lines = append(lines, c.Line)
+		}
+	}
+	sort.Ints(lines)
+
+	suppressions := map[int][]string{}
+	for _, comment := range comments {
+		rest := strings.TrimPrefix(comment.Text, lintIgnoreDirective)
+		if rest == comment.Text || rest != "" && !unicode.IsSpace([]rune(rest)[0]) {
+			// not a directive, or prose like lint:ignored
+			continue
+		}
+		i := sort.SearchInts(lines, comment.Line)
+		if i == len(lines) {
+			continue
+		}
+		rules := strings.Fields(strings.Replace(rest, ",", " ", -1))
+		if len(rules) == 0 {
+			rules = []string{"*"}
+		}
+		suppressions[lines[i]] = append(suppressions[lines[i]], rules...)
+	}
+	return suppressions
+}
+
+func suppressed(suppressions map[int][]string, issue LintIssue) bool {
+	for _, rule := range suppressions[issue.Line] {
+		if rule == "*" || rule == issue.Rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs every enabled rule against the parsed tables and returns the
+// issues that are not suppressed, ordered by line.
+func Lint(p *Parser, config LintConfig) []LintIssue {
+	suppressions := lintSuppressions(p.Tables(), p.Comments())
+
+	var issues []LintIssue
+	for _, rule := range lintRules {
+		severity := config.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		for _, t := range p.Tables() {
+			c := &LintContext{rule: rule, table: t}
+			rule.Check(c, t)
+			for _, issue := range c.issues {
+				if suppressed(suppressions, issue) {
+					continue
+				}
+				issue.Severity = severity
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return issues
+}
+
+func ExportLintText(name string, issues []LintIssue, wr io.Writer) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(wr, "%s:%d: %s: %s [%s]\n", name, issue.Line, issue.Severity, issue.Message, issue.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ExportLintJSON(issues []LintIssue, wr io.Writer) error {
+	if issues == nil {
+		issues = []LintIssue{}
+	}
+	data, err := json.Marshal(issues)
+	if err != nil {
+		return err
+	}
+
+	if _, err := wr.Write(data); err != nil {
+		return err
+	}
+	return nil
+}
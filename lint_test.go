@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func lintRuleNames(issues []LintIssue) []string {
+	var names []string
+	for _, issue := range issues {
+		names = append(names, issue.Rule)
+	}
+	return names
+}
+
+func TestLint(t *testing.T) {
+	Convey("Default rules", t, func() {
+		err, parser := parse(t, `
+Users {
+  name
+  blogId -> blogs.id
+}
+
+blogs : All blogs {
+  id
+}`)
+		So(err, ShouldBeNil)
+		issues := Lint(parser, LintConfig{})
+		So(lintRuleNames(issues), ShouldResemble, []string{
+			"snake-case", "table-description", "primary-key", "snake-case", "fk-suffix",
+		})
+		So(issues[2].Severity, ShouldEqual, SeverityError)
+		So(issues[3].Line, ShouldEqual, 4)
+		So(issues[3].Column, ShouldEqual, "blogId")
+	})
+
+	Convey("Configured severity", t, func() {
+		err, parser := parse(t, `
+users {
+  id
+}`)
+		So(err, ShouldBeNil)
+		issues := Lint(parser, LintConfig{Rules: map[string]string{
+			"table-description":   "off",
+			"singular-table-name": "error",
+		}})
+		So(lintRuleNames(issues), ShouldResemble, []string{"singular-table-name"})
+		So(issues[0].Severity, ShouldEqual, SeverityError)
+	})
+
+	Convey("Suppression comments", t, func() {
+		err, parser := parse(t, `
+# lint:ignore table-description
+Users {
+  id
+  blogId -> blogs.id # lint:ignore
+}`)
+		So(err, ShouldBeNil)
+		So(lintRuleNames(Lint(parser, LintConfig{})), ShouldResemble, []string{"snake-case"})
+	})
+
+	Convey("Suppression comments after a description", t, func() {
+		err, parser := parse(t, `
+posts : blog posts {
+  id
+  BlogId BIGINT -> blogs.id : the blog # lint:ignore snake-case
+}`)
+		So(err, ShouldBeNil)
+		So(parser.Tables()[0].Columns[1].Description, ShouldEqual, "the blog")
+		So(lintRuleNames(Lint(parser, LintConfig{})), ShouldResemble, []string{"fk-suffix"})
+	})
+
+	Convey("Comments merely starting like the directive suppress nothing", t, func() {
+		err, parser := parse(t, `
+# lint:ignored because it is legacy
+users {
+  id
+}`)
+		So(err, ShouldBeNil)
+		So(lintSuppressions(parser.Tables(), parser.Comments()), ShouldBeEmpty)
+		So(lintRuleNames(Lint(parser, LintConfig{})), ShouldResemble, []string{"table-description"})
+	})
+
+	Convey("Plural table names", t, func() {
+		So(isPlural("users"), ShouldBeTrue)
+		So(isPlural("blog_categories"), ShouldBeTrue)
+		So(isPlural("people"), ShouldBeTrue)
+		So(isPlural("address"), ShouldBeFalse)
+		So(isPlural("status"), ShouldBeFalse)
+		So(isPlural("user"), ShouldBeFalse)
+
+		So(pluralize("post"), ShouldEqual, "posts")
+		So(pluralize("blog_category"), ShouldEqual, "blog_categories")
+		So(pluralize("day"), ShouldEqual, "days")
+		So(pluralize("address"), ShouldEqual, "addresses")
+		So(pluralize("box"), ShouldEqual, "boxes")
+		So(pluralize("users"), ShouldEqual, "users")
+	})
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	_ "embed"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"text/template"
 
-	"encoding/json"
 	"github.com/urfave/cli"
 	"io"
 )
@@ -40,12 +42,20 @@ type Column struct {
 	Relation    *Relation
 	Description string
 	Type        string
+	Line        int `json:"-"`
 }
 
 type Table struct {
 	Name        string
 	Description string
 	Columns     []Column
+	Line        int `json:"-"`
+}
+
+// Comment is a "#" comment found in the source, without the leading "#".
+type Comment struct {
+	Line int
+	Text string
 }
 
 func (t Table) ColumnsWithRelation() []Column {
@@ -58,6 +68,37 @@ func (t Table) ColumnsWithRelation() []Column {
 	return ret
 }
 
+// Reference is a column of a table whose relation points at another table.
+type Reference struct {
+	Table  Table
+	Column Column
+}
+
+// ReferencesTo returns the columns of tables which have a relation to the
+// table named name.
+func ReferencesTo(tables []Table, name string) []Reference {
+	var ret []Reference
+	for _, t := range tables {
+		for _, c := range t.ColumnsWithRelation() {
+			if c.Relation.TableName == name {
+				ret = append(ret, Reference{Table: t, Column: c})
+			}
+		}
+	}
+	return ret
+}
+
+// PrimaryKey returns the column named "id", which is the primary key by
+// convention, or nil if the table has none.
+func (t Table) PrimaryKey() *Column {
+	for i, c := range t.Columns {
+		if c.Name == "id" {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
 func ReadStdin() string {
 	buf, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
@@ -66,65 +107,104 @@ func ReadStdin() string {
 	return string(buf)
 }
 
+// ReadSource reads the erd source from the file given as the first argument,
+// or from stdin if there is none. It returns the name to use in messages.
+func ReadSource(c *cli.Context) (string, string, error) {
+	if !c.Args().Present() {
+		return "<stdin>", ReadStdin(), nil
+	}
+	name := c.Args().First()
+	buf, err := ioutil.ReadFile(name)
+	if err != nil {
+		return name, "", err
+	}
+	return name, string(buf), nil
+}
+
 type ParsedData interface {
 	Tables() []Table
 }
 
+// Schema is ParsedData made of tables built without the parser, e.g. imported
+// from another format.
+type Schema []Table
+
+func (s Schema) Tables() []Table {
+	return s
+}
+
 func (p Parser) Tables() []Table {
 	return p.tables
 }
 
-func ExportDot(p ParsedData, wr io.Writer) error {
-	tmpl, err := template.New("test").Parse(`
-digraph er {
-	graph [rankdir=LR];
-	ranksep="1.2";
-	overlap=false;
-	splines=true;
-	sep="+30,30";
-	node [shape=plaintext];
-{{range .Tables}}
-{{.Name}}[label=<
-<TABLE STYLE="RADIAL" BORDER="1" CELLBORDER="0" CELLSPACING="1" ROWS="*">
-  <TR><TD><B>{{.Name}}</B>{{if .Description}}<br />{{.Description}}{{end}}</TD></TR>
-  {{range .Columns}}
-    <TR><TD PORT="{{.Name}}" ALIGN="LEFT"><B>{{.Name}}</B> {{if .Type }}<I>{{.Type}}</I>{{end}} {{.Description}}</TD></TR>
-  {{end}}
-</TABLE>
->];
-{{end}}
-
-{{range $table := .Tables}}
-{{range $column := $table.ColumnsWithRelation}}
-{{$table.Name}}:{{$column.Name}} -> {{$column.Relation.TableName}}:{{$column.Relation.ColumnName}} [style="{{$column.Relation.LineStyleLiteral}}"];
-{{end}}
-{{end}}
-}
-		`)
-	if err != nil {
-		return err
+func (p Parser) Comments() []Comment {
+	return p.comments
+}
+
+// lineOf returns the 1-based line number of the rune offset pos.
+func (p *Parser) lineOf(pos int) int {
+	if p.newlines == nil {
+		p.newlines = []int{}
+		for i, r := range p.buffer {
+			if r == '\n' {
+				p.newlines = append(p.newlines, i)
+			}
+		}
 	}
+	return sort.SearchInts(p.newlines, pos) + 1
+}
 
-	err = tmpl.Execute(os.Stdout, p)
-	if err != nil {
-		return err
+// ParseText parses the erd source text and executes the parsed actions.
+func ParseText(text string) (*Parser, error) {
+	parser := &Parser{Buffer: text}
+	parser.Init()
+	if err := parser.Parse(); err != nil {
+		return nil, err
 	}
+	parser.Execute()
+	return parser, nil
+}
 
-	return nil
+// ParseInput reads text written in the given input format: erd, or dbml or
+// prisma to import it.
+func ParseInput(format, text string) (ParsedData, error) {
+	switch format {
+	case "erd":
+		return ParseText(text)
+	case "dbml":
+		return ParseDBML(text)
+	case "prisma":
+		return ParsePrisma(text)
+	}
+	return nil, fmt.Errorf("unknown input format %q (available: erd, dbml, prisma)", format)
 }
 
-func ExportJSON(p ParsedData, wr io.Writer) error {
-	data, err := json.Marshal(p.Tables())
+//go:embed templates/dot.tmpl
+var dotTemplate string
+
+// DotTemplate is the text/template ExportDot renders. It can be replaced to
+// change the generated dot file.
+var DotTemplate = dotTemplate
+
+// ExportTemplate renders the text/template source text with p as its data.
+func ExportTemplate(p ParsedData, text string, wr io.Writer) error {
+	tmpl, err := template.New("erd").Parse(text)
 	if err != nil {
 		return err
 	}
 
-	if _, err := wr.Write(data); err != nil {
+	err = tmpl.Execute(wr, p)
+	if err != nil {
 		return err
 	}
+
 	return nil
 }
 
+func ExportDot(p ParsedData, wr io.Writer) error {
+	return ExportTemplate(p, DotTemplate, wr)
+}
+
 func main() {
 
 	app := cli.NewApp()
@@ -132,41 +212,206 @@ func main() {
 	app.Usage = "Yet another ER Diagram Maker"
 	app.Version = "0.0.1"
 	app.Commands = []cli.Command{
+		convertCommand(os.Stdout),
 		{
-			Name:    "convert",
-			Aliases: []string{"c"},
-			Usage:   "convert erd file to dot/json",
+			Name:      "lint",
+			Aliases:   []string{"l"},
+			Usage:     "check erd file against style rules",
+			ArgsUsage: "[file]",
 			Flags: []cli.Flag{
 				cli.StringFlag{
-					Name:  "outformat",
-					Value: "dot",
-					Usage: "output format. dot and json is available.",
+					Name:  "config",
+					Usage: "JSON file setting the severity (off, warning or error) of each rule.",
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Value: "text",
+					Usage: "output format. text and json is available.",
+				},
+				cli.BoolFlag{
+					Name:  "rules",
+					Usage: "list the available rules and exit.",
 				},
 			},
 			Action: func(c *cli.Context) error {
-				text := ReadStdin()
+				if c.Bool("rules") {
+					for _, rule := range LintRules() {
+						fmt.Printf("%-20s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+					}
+					return nil
+				}
 
-				parser := &Parser{Buffer: text} // 解析対象文字の設定
-				parser.Init()                   // parser初期化
-				err := parser.Parse()           // 解析
+				var config LintConfig
+				if path := c.String("config"); path != "" {
+					var err error
+					config, err = LoadLintConfig(path)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+				}
+
+				name, text, err := ReadSource(c)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				parser, err := ParseText(text)
 				if err != nil {
 					return cli.NewExitError(err.Error(), 1)
 				}
 
-				parser.Execute()
-
-				outFormat := c.String("outformat")
-				if outFormat == "json" {
-					err = ExportJSON(parser, os.Stdout)
+				issues := Lint(parser, config)
+				if c.String("format") == "json" {
+					err = ExportLintJSON(issues, os.Stdout)
 				} else {
-					err = ExportDot(parser, os.Stdout)
+					err = ExportLintText(name, issues, os.Stdout)
 				}
 				if err != nil {
 					return cli.NewExitError(err.Error(), 1)
 				}
+
+				for _, issue := range issues {
+					if issue.Severity == SeverityError {
+						return cli.NewExitError("", 1)
+					}
+				}
 				return nil
 			},
 		},
+		{
+			Name:      "doc",
+			Aliases:   []string{"d"},
+			Usage:     "generate an HTML documentation site",
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "o, output",
+					Value: "site",
+					Usage: "directory to write the site to.",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				_, text, err := ReadSource(c)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				parser, err := ParseText(text)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+
+				for _, mismatch := range ResolveTypes(parser.Tables()) {
+					fmt.Fprintf(os.Stderr, "warning: %v\n", mismatch)
+				}
+
+				if err := GenerateDoc(parser, c.String("output")); err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "fmt",
+			Aliases:   []string{"f"},
+			Usage:     "reformat erd files canonically",
+			ArgsUsage: "[files...]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "w",
+					Usage: "write the result to the file instead of stdout.",
+				},
+				cli.BoolFlag{
+					Name:  "d",
+					Usage: "print a diff instead of the reformatted source.",
+				},
+				cli.BoolFlag{
+					Name:  "l",
+					Usage: "list the files whose formatting differs.",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if !c.Args().Present() {
+					if c.Bool("w") {
+						return cli.NewExitError("cannot use -w with stdin", 1)
+					}
+					text := ReadStdin()
+					formatted, err := FormatSource(text)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					if c.Bool("d") {
+						os.Stdout.Write(UnifiedDiff("<stdin>", []byte(text), formatted))
+					} else if c.Bool("l") {
+						if text != string(formatted) {
+							fmt.Println("<stdin>")
+						}
+					} else {
+						os.Stdout.Write(formatted)
+					}
+					return nil
+				}
+
+				for _, name := range c.Args() {
+					info, err := os.Stat(name)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					text, err := ioutil.ReadFile(name)
+					if err != nil {
+						return cli.NewExitError(err.Error(), 1)
+					}
+					formatted, err := FormatSource(string(text))
+					if err != nil {
+						return cli.NewExitError(name+": "+err.Error(), 1)
+					}
+
+					changed := string(text) != string(formatted)
+					if c.Bool("l") && changed {
+						fmt.Println(name)
+					}
+					if c.Bool("w") && changed {
+						if err := ioutil.WriteFile(name, formatted, info.Mode()); err != nil {
+							return cli.NewExitError(err.Error(), 1)
+						}
+					}
+					if c.Bool("d") {
+						os.Stdout.Write(UnifiedDiff(name, text, formatted))
+					}
+					if !c.Bool("w") && !c.Bool("d") && !c.Bool("l") {
+						os.Stdout.Write(formatted)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "gen",
+			Aliases: []string{"g"},
+			Usage:   "generate code from erd file",
+			Subcommands: []cli.Command{
+				genCommand("go", "generate Go structs", GoTypes, []cli.Flag{
+					cli.StringFlag{
+						Name:  "package",
+						Value: "models",
+						Usage: "package of the generated file.",
+					},
+				}, func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error {
+					return GenerateGo(p, c.String("package"), types, wr)
+				}, nil),
+				genCommand("typescript", "generate TypeScript interfaces", TypeScriptTypes, nil,
+					func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error {
+						return GenerateTypeScript(p, types, wr)
+					}, nil),
+				genProtoCommand(),
+				genCommand("sqlalchemy", "generate SQLAlchemy models", SQLAlchemyTypes, nil,
+					func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error {
+						return GenerateSQLAlchemy(p, types, wr)
+					}, nil),
+				genCommand("django", "generate Django models", DjangoTypes, nil,
+					func(c *cli.Context, p ParsedData, types TypeMap, wr io.Writer) error {
+						return GenerateDjango(p, types, wr)
+					}, nil),
+			},
+		},
 	}
 
 	app.Run(os.Args)
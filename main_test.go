@@ -1,10 +1,44 @@
 package main
 
 import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// golden compares out with testdata/name, rewriting the file when -update is given.
+func golden(t *testing.T, name string, out []byte) string {
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, out, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(want)
+}
+
+// parseSample parses sample.erd.
+func parseSample(t *testing.T) *Parser {
+	source, err := ioutil.ReadFile("sample.erd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err, parser := parse(t, string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parser
+}
+
 func parse(t *testing.T, code string) (error, *Parser) {
 	parser := &Parser{Buffer: code}
 	parser.Init()                 // parser初期化
@@ -113,6 +147,20 @@ devices {
 		So(parser.Tables()[0].Columns[2].Description, ShouldEqual, "User unique token.")
 	})
 
+	Convey("Descriptions end at a comment", t, func() {
+		err, parser := parse(t, `
+devices : "#" of devices { # of users
+  id : the "#" of the device # primary key
+  token : "#1" token #
+}`)
+		So(err, ShouldBeNil)
+		So(parser.Tables()[0].Description, ShouldEqual, `"#" of devices`)
+		So(parser.Tables()[0].Columns[0].Description, ShouldEqual, `the "#" of the device`)
+		So(parser.Tables()[0].Columns[1].Description, ShouldEqual, `"#1" token`)
+		So(len(parser.Comments()), ShouldEqual, 3)
+		So(parser.Comments()[1].Text, ShouldEqual, "primary key")
+	})
+
 	Convey("Table Description", t, func() {
 		err, parser := parse(t, `
 devices : devices including iOS/Android {
@@ -167,4 +215,50 @@ devices : devices including iOS/Android {
 		So(parser.Tables()[0].Columns[1].Relation.ColumnName, ShouldEqual, "id")
 		So(parser.Tables()[0].Columns[1].Relation.LineType, ShouldEqual, NormalLine)
 	})
+
+	Convey("Comments", t, func() {
+		err, parser := parse(t, `
+# device tokens
+devices {
+  id INT # primary key
+  # owner
+  user_id -> users.id
+}
+# trailing`)
+		So(err, ShouldBeNil)
+		So(len(parser.Tables()), ShouldEqual, 1)
+		So(parser.Tables()[0].Line, ShouldEqual, 3)
+		So(parser.Tables()[0].Columns[0].Type, ShouldEqual, "INT")
+		So(parser.Tables()[0].Columns[1].Line, ShouldEqual, 6)
+		So(len(parser.Comments()), ShouldEqual, 4)
+		So(parser.Comments()[0].Text, ShouldEqual, "device tokens")
+		So(parser.Comments()[1].Line, ShouldEqual, 4)
+		So(parser.Comments()[3].Text, ShouldEqual, "trailing")
+	})
+
+	Convey("Dot export writes to the writer", t, func() {
+		err, parser := parse(t, `
+devices {
+  id
+  user_id ..> users.id
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportDot(parser, &buf), ShouldBeNil)
+		So(buf.String(), ShouldStartWith, "digraph er {")
+		So(buf.String(), ShouldContainSubstring, `devices:user_id -> users:id [style="dotted"];`)
+	})
+
+	Convey("Custom template", t, func() {
+		err, parser := parse(t, `
+devices : All devices {
+  id
+  token
+}`)
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		So(ExportTemplate(parser, `{{range .Tables}}{{.Name}}: {{.Description}} ({{len .Columns}} columns){{end}}`, &buf), ShouldBeNil)
+		So(buf.String(), ShouldEqual, "devices: All devices (2 columns)")
+		So(ExportTemplate(parser, `{{.Unknown}}`, &buf), ShouldNotBeNil)
+	})
 }
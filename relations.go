@@ -0,0 +1,101 @@
+package main
+
+import "strings"
+
+// relationType is a table written as a type with a field per column, like a
+// Prisma model or a GraphQL object type, to which relations add fields.
+type relationType struct {
+	Table Table
+	Name  string
+	// Fields are the names of the fields of the columns.
+	Fields map[string]string
+	names  uniqueNames
+}
+
+// newRelationTypes returns the types of the tables, named by typeName and
+// with fields named by fieldName, made unique. Tables defined twice get the
+// type of their first definition.
+func newRelationTypes(tables []Table, typeName, fieldName func(string) string) []*relationType {
+	var types []*relationType
+	seen := map[string]bool{}
+	typeNames := uniqueNames{}
+	for _, t := range tables {
+		if seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		rt := &relationType{Table: t, Name: typeNames.add(typeName(t.Name)), Fields: map[string]string{}, names: uniqueNames{}}
+		for _, c := range t.Columns {
+			rt.Fields[c.Name] = rt.names.add(fieldName(c.Name))
+		}
+		types = append(types, rt)
+	}
+	return types
+}
+
+// relationNames names the fields of a relation to a column of a known
+// table: Field on the type of Table, and BackField on the one of Target.
+type relationNames struct {
+	Table, Target Table
+	Column        Column
+	// Ambiguous tells whether there are other relations between the two
+	// types, or the relation is a self-relation, so that it needs a name.
+	Ambiguous        bool
+	Field, BackField string
+}
+
+// relationFieldNames returns the relations of the columns to columns of the
+// types. A relation field is named after its column without _id, and the
+// field back is the plural of the type, or the type alone when the column is
+// the primary key, followed by the relation field if it is ambiguous.
+func relationFieldNames(types []*relationType) []*relationNames {
+	byTable := map[string]*relationType{}
+	for _, rt := range types {
+		byTable[rt.Table.Name] = rt
+	}
+	pair := func(a, b *relationType) [2]string {
+		if a.Name > b.Name {
+			return [2]string{b.Name, a.Name}
+		}
+		return [2]string{a.Name, b.Name}
+	}
+
+	// how many relations there are between each pair of types
+	var relations []*relationNames
+	pairs := map[[2]string]int{}
+	for _, rt := range types {
+		for _, c := range rt.Table.ColumnsWithRelation() {
+			target, ok := byTable[c.Relation.TableName]
+			if !ok {
+				continue
+			}
+			if _, ok := target.Fields[c.Relation.ColumnName]; !ok {
+				continue
+			}
+			relations = append(relations, &relationNames{Table: rt.Table, Target: target.Table, Column: c})
+			pairs[pair(rt, target)]++
+		}
+	}
+	for _, r := range relations {
+		rt, target := byTable[r.Table.Name], byTable[r.Target.Name]
+		column := rt.Fields[r.Column.Name]
+		field := strings.TrimSuffix(strings.TrimSuffix(column, "_id"), "Id")
+		if field == column || field == "" {
+			field = column + "_ref"
+		}
+		r.Field = rt.names.add(field)
+		r.Ambiguous = pairs[pair(rt, target)] > 1 || rt == target
+	}
+	for _, r := range relations {
+		rt, target := byTable[r.Table.Name], byTable[r.Target.Name]
+		back := lowerFirst(rt.Name)
+		if pk := r.Table.PrimaryKey(); pk == nil || pk.Name != r.Column.Name {
+			back = pluralize(back)
+		}
+		if r.Ambiguous {
+			back += "_" + r.Field
+		}
+		r.BackField = target.names.add(back)
+	}
+	return relations
+}
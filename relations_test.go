@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRelationFieldNames(t *testing.T) {
+	Convey("Relations get fields on both types", t, func() {
+		err, parser := parse(t, `
+messages {
+  id
+  sender_id -> users.id
+  recipient_id -> users.id
+  code -> users.code
+  reply_id -> messages.id
+}
+
+users {
+  id
+  code
+}
+
+profiles {
+  id -> users.id
+  user_id -> missing.id
+}`)
+		So(err, ShouldBeNil)
+		types := newRelationTypes(parser.Tables(), tsName, graphqlFieldName)
+		var names [][]interface{}
+		for _, r := range relationFieldNames(types) {
+			names = append(names, []interface{}{r.Table.Name + "." + r.Column.Name, r.Field, r.BackField, r.Ambiguous})
+		}
+		So(names, ShouldResemble, [][]interface{}{
+			{"messages.sender_id", "sender", "messages_sender", true},
+			{"messages.recipient_id", "recipient", "messages_recipient", true},
+			{"messages.code", "code_ref", "messages_code_ref", true},
+			{"messages.reply_id", "reply", "messages_reply", true},
+			{"profiles.id", "id_ref", "profiles", false},
+		})
+	})
+}
@@ -0,0 +1,44 @@
+# Code generated by erd gen django. DO NOT EDIT.
+
+from django.db import models
+
+
+class User(models.Model):
+    """All our customers"""
+
+    id = models.BigIntegerField(primary_key=True)
+    email = models.CharField(max_length=128, help_text="User's email address")
+    name = models.TextField(help_text="user's name")
+
+    class Meta:
+        db_table = "User"
+
+
+class Post(models.Model):
+    id = models.BigIntegerField(primary_key=True)
+    blog = models.ForeignKey("Blog", on_delete=models.DO_NOTHING)
+    category = models.ForeignKey("Category", on_delete=models.DO_NOTHING)
+    title = models.TextField(help_text="title of the blog post")
+    text = models.TextField(help_text="plain text content of the blog post")
+
+    class Meta:
+        db_table = "Post"
+
+
+class Blog(models.Model):
+    id = models.BigIntegerField(primary_key=True)
+    user = models.ForeignKey("User", on_delete=models.DO_NOTHING)
+    name = models.TextField()
+
+    class Meta:
+        db_table = "Blog"
+
+
+class Category(models.Model):
+    id = models.BigIntegerField(primary_key=True)
+    name = models.TextField()
+    parent_category = models.ForeignKey("self", on_delete=models.DO_NOTHING)
+    blog = models.ForeignKey("Blog", on_delete=models.DO_NOTHING)
+
+    class Meta:
+        db_table = "Category"
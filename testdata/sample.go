@@ -0,0 +1,40 @@
+// Code generated by erd gen go. DO NOT EDIT.
+
+package models
+
+// User is a row of the table User.
+//
+// All our customers
+type User struct {
+	ID int64 `db:"id" json:"id"`
+	// User's email address
+	Email string `db:"email" json:"email"`
+	// user's name
+	Name string `db:"name" json:"name"`
+}
+
+// Post is a row of the table Post.
+type Post struct {
+	ID         int64 `db:"id" json:"id"`
+	BlogID     int64 `db:"blog_id" json:"blog_id"`
+	CategoryID int64 `db:"category_id" json:"category_id"`
+	// title of the blog post
+	Title string `db:"title" json:"title"`
+	// plain text content of the blog post
+	Text string `db:"text" json:"text"`
+}
+
+// Blog is a row of the table Blog.
+type Blog struct {
+	ID     int64  `db:"id" json:"id"`
+	UserID int64  `db:"user_id" json:"user_id"`
+	Name   string `db:"name" json:"name"`
+}
+
+// Category is a row of the table Category.
+type Category struct {
+	ID               int64  `db:"id" json:"id"`
+	Name             string `db:"name" json:"name"`
+	ParentCategoryID int64  `db:"parent_category_id" json:"parent_category_id"`
+	BlogID           int64  `db:"blog_id" json:"blog_id"`
+}
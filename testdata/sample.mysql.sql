@@ -0,0 +1,32 @@
+CREATE TABLE `User` (
+  `id` BIGINT PRIMARY KEY,
+  `email` varchar(128) COMMENT 'User''s email address',
+  `name` VARCHAR(255) COMMENT 'user''s name'
+) COMMENT='All our customers';
+
+CREATE TABLE `Blog` (
+  `id` BIGINT PRIMARY KEY,
+  `user_id` BIGINT,
+  `name` VARCHAR(255),
+  CONSTRAINT `fk_Blog_user_id` FOREIGN KEY (`user_id`) REFERENCES `User` (`id`)
+);
+
+CREATE TABLE `Category` (
+  `id` BIGINT PRIMARY KEY,
+  `name` VARCHAR(255),
+  `parent_category_id` BIGINT,
+  `blog_id` BIGINT,
+  CONSTRAINT `fk_Category_blog_id` FOREIGN KEY (`blog_id`) REFERENCES `Blog` (`id`)
+);
+
+CREATE TABLE `Post` (
+  `id` BIGINT PRIMARY KEY,
+  `blog_id` BIGINT,
+  `category_id` BIGINT,
+  `title` VARCHAR(255) COMMENT 'title of the blog post',
+  `text` VARCHAR(255) COMMENT 'plain text content of the blog post',
+  CONSTRAINT `fk_Post_blog_id` FOREIGN KEY (`blog_id`) REFERENCES `Blog` (`id`),
+  CONSTRAINT `fk_Post_category_id` FOREIGN KEY (`category_id`) REFERENCES `Category` (`id`)
+);
+
+ALTER TABLE `Category` ADD CONSTRAINT `fk_Category_parent_category_id` FOREIGN KEY (`parent_category_id`) REFERENCES `Category` (`id`);
@@ -0,0 +1,33 @@
+CREATE TABLE "User" (
+  "id" BIGINT PRIMARY KEY,
+  "email" varchar(128),
+  "name" TEXT
+);
+COMMENT ON TABLE "User" IS 'All our customers';
+COMMENT ON COLUMN "User"."email" IS 'User''s email address';
+COMMENT ON COLUMN "User"."name" IS 'user''s name';
+
+CREATE TABLE "Blog" (
+  "id" BIGINT PRIMARY KEY,
+  "user_id" BIGINT REFERENCES "User" ("id"),
+  "name" TEXT
+);
+
+CREATE TABLE "Category" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT,
+  "parent_category_id" BIGINT,
+  "blog_id" BIGINT REFERENCES "Blog" ("id")
+);
+
+CREATE TABLE "Post" (
+  "id" BIGINT PRIMARY KEY,
+  "blog_id" BIGINT REFERENCES "Blog" ("id"),
+  "category_id" BIGINT REFERENCES "Category" ("id"),
+  "title" TEXT,
+  "text" TEXT
+);
+COMMENT ON COLUMN "Post"."title" IS 'title of the blog post';
+COMMENT ON COLUMN "Post"."text" IS 'plain text content of the blog post';
+
+ALTER TABLE "Category" ADD CONSTRAINT "fk_Category_parent_category_id" FOREIGN KEY ("parent_category_id") REFERENCES "Category" ("id");
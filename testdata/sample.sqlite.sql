@@ -0,0 +1,27 @@
+-- All our customers
+CREATE TABLE "User" (
+  "id" INTEGER PRIMARY KEY,
+  "email" varchar(128), -- User's email address
+  "name" TEXT -- user's name
+);
+
+CREATE TABLE "Blog" (
+  "id" INTEGER PRIMARY KEY,
+  "user_id" INTEGER REFERENCES "User" ("id"),
+  "name" TEXT
+);
+
+CREATE TABLE "Category" (
+  "id" INTEGER PRIMARY KEY,
+  "name" TEXT,
+  "parent_category_id" INTEGER REFERENCES "Category" ("id"),
+  "blog_id" INTEGER REFERENCES "Blog" ("id")
+);
+
+CREATE TABLE "Post" (
+  "id" INTEGER PRIMARY KEY,
+  "blog_id" INTEGER REFERENCES "Blog" ("id"),
+  "category_id" INTEGER REFERENCES "Category" ("id"),
+  "title" TEXT, -- title of the blog post
+  "text" TEXT -- plain text content of the blog post
+);
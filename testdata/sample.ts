@@ -0,0 +1,33 @@
+// Code generated by erd gen typescript. DO NOT EDIT.
+
+/** All our customers */
+export interface User {
+  id: number;
+  /** User's email address */
+  email: string;
+  /** user's name */
+  name: string;
+}
+
+export interface Post {
+  id: number;
+  blog_id: Blog["id"];
+  category_id: Category["id"];
+  /** title of the blog post */
+  title: string;
+  /** plain text content of the blog post */
+  text: string;
+}
+
+export interface Blog {
+  id: number;
+  user_id: User["id"];
+  name: string;
+}
+
+export interface Category {
+  id: number;
+  name: string;
+  parent_category_id: Category["id"];
+  blog_id: Blog["id"];
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TypeMismatch reports a column whose explicit type disagrees with the type
+// of the column its relation points to.
+type TypeMismatch struct {
+	Table      string
+	Column     string
+	Line       int
+	Type       string
+	Target     string
+	TargetType string
+}
+
+func (m TypeMismatch) Error() string {
+	return fmt.Sprintf("line %d: %s.%s is %s but references %s which is %s",
+		m.Line, m.Table, m.Column, m.Type, m.Target, m.TargetType)
+}
+
+var typeAliases = map[string]string{
+	"INTEGER": "INT",
+	"INT4":    "INT",
+	"INT8":    "BIGINT",
+	"BOOL":    "BOOLEAN",
+}
+
+// normalizeType makes spellings of the same type compare equal.
+func normalizeType(t string) string {
+	t = strings.ToUpper(strings.Join(strings.Fields(t), ""))
+	if alias, ok := typeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+var (
+	typeNullability = regexp.MustCompile(`(?i)(^|\s+)(not\s+)?null$`)
+	// typeConstraint matches the UNIQUE and PRIMARY KEY constraints a type
+	// may have besides its nullability, like "BIGINT UNIQUE NOT NULL".
+	typeConstraint = regexp.MustCompile(`(?i)(^|\s+)(unique|primary\s+key)\b`)
+)
+
+// typeConstraints returns a column type without its UNIQUE and PRIMARY KEY
+// constraints, and whether it has them.
+func typeConstraints(t string) (rest string, unique, primaryKey bool) {
+	for _, m := range typeConstraint.FindAllStringSubmatch(t, -1) {
+		if strings.EqualFold(m[2], "unique") {
+			unique = true
+		} else {
+			primaryKey = true
+		}
+	}
+	return typeConstraint.ReplaceAllString(t, ""), unique, primaryKey
+}
+
+// baseType returns a column type without its NULL or NOT NULL and its
+// constraints.
+func baseType(t string) string {
+	rest, _, _ := typeConstraints(t)
+	return typeNullability.ReplaceAllString(rest, "")
+}
+
+// BaseType returns the type of the column without its NULL or NOT NULL and
+// its constraints.
+func (c Column) BaseType() string {
+	return baseType(c.Type)
+}
+
+// Nullable tells whether the column may be NULL, which a type ending with
+// NULL declares, like "TEXT NULL". Other columns are taken as NOT NULL.
+func (c Column) Nullable() bool {
+	rest, _, _ := typeConstraints(c.Type)
+	m := typeNullability.FindStringSubmatch(rest)
+	return m != nil && m[2] == ""
+}
+
+// ResolveTypes gives every untyped column with a relation the type of the
+// column it references, without its nullability, following chains of
+// relations. The tables are modified in place. It returns the columns whose
+// explicit type disagrees with their target, nullability aside.
+func ResolveTypes(tables []Table) []TypeMismatch {
+	columns := map[string]*Column{}
+	explicit := map[*Column]bool{}
+	for i := range tables {
+		for j := range tables[i].Columns {
+			c := &tables[i].Columns[j]
+			columns[tables[i].Name+"."+c.Name] = c
+			explicit[c] = c.Type != ""
+		}
+	}
+
+	target := func(c *Column) *Column {
+		return columns[c.Relation.TableName+"."+c.Relation.ColumnName]
+	}
+
+	var resolve func(c *Column, seen map[*Column]bool) string
+	resolve = func(c *Column, seen map[*Column]bool) string {
+		if c.Type != "" || c.Relation == nil || seen[c] {
+			return c.Type
+		}
+		seen[c] = true
+		if t := target(c); t != nil {
+			c.Type = baseType(resolve(t, seen))
+		}
+		return c.Type
+	}
+
+	var mismatches []TypeMismatch
+	for i := range tables {
+		for j := range tables[i].Columns {
+			c := &tables[i].Columns[j]
+			if c.Relation == nil {
+				continue
+			}
+			if !explicit[c] {
+				resolve(c, map[*Column]bool{})
+				continue
+			}
+			t := target(c)
+			if t == nil {
+				continue
+			}
+			targetType := resolve(t, map[*Column]bool{c: true})
+			if targetType != "" && normalizeType(c.BaseType()) != normalizeType(baseType(targetType)) {
+				mismatches = append(mismatches, TypeMismatch{
+					Table:      tables[i].Name,
+					Column:     c.Name,
+					Line:       c.Line,
+					Type:       c.Type,
+					Target:     c.Relation.TableName + "." + c.Relation.ColumnName,
+					TargetType: targetType,
+				})
+			}
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolveTypes(t *testing.T) {
+	Convey("Untyped relation columns take the target type", t, func() {
+		err, parser := parse(t, `
+posts {
+  id
+  comment_id -> comments.id
+}
+
+comments {
+  id BIGINT
+  post_id -> posts.id
+  parent_id ..> comments.id
+}
+
+replies {
+  comment_id -> posts.comment_id
+}`)
+		So(err, ShouldBeNil)
+		So(ResolveTypes(parser.Tables()), ShouldBeEmpty)
+		So(parser.Tables()[0].Columns[1].Type, ShouldEqual, "BIGINT")
+		So(parser.Tables()[1].Columns[1].Type, ShouldEqual, "")
+		So(parser.Tables()[1].Columns[2].Type, ShouldEqual, "BIGINT")
+		So(parser.Tables()[2].Columns[0].Type, ShouldEqual, "BIGINT")
+	})
+
+	Convey("Explicit types that disagree are reported", t, func() {
+		err, parser := parse(t, `
+posts {
+  id
+  blog_id INT -> blogs.id
+  user_id integer -> users.id
+}
+
+blogs {
+  id BIGINT
+}
+
+users {
+  id INT
+}`)
+		So(err, ShouldBeNil)
+		mismatches := ResolveTypes(parser.Tables())
+		So(len(mismatches), ShouldEqual, 1)
+		So(mismatches[0].Column, ShouldEqual, "blog_id")
+		So(mismatches[0].Line, ShouldEqual, 4)
+		So(mismatches[0].Error(), ShouldEqual, "line 4: posts.blog_id is INT but references blogs.id which is BIGINT")
+		So(parser.Tables()[0].Columns[1].Type, ShouldEqual, "INT")
+	})
+
+	Convey("Nullability is not part of the type", t, func() {
+		err, parser := parse(t, `
+posts {
+  id BIGINT NOT NULL
+  parent_id BIGINT NULL -> posts.id
+  editor_id -> posts.parent_id
+}`)
+		So(err, ShouldBeNil)
+		So(ResolveTypes(parser.Tables()), ShouldBeEmpty)
+		columns := parser.Tables()[0].Columns
+		So(columns[0].Nullable(), ShouldBeFalse)
+		So(columns[0].BaseType(), ShouldEqual, "BIGINT")
+		So(columns[1].Nullable(), ShouldBeTrue)
+		So(columns[1].BaseType(), ShouldEqual, "BIGINT")
+		So(columns[2].Type, ShouldEqual, "BIGINT")
+		So(columns[2].Nullable(), ShouldBeFalse)
+		So(Column{Type: "text null"}.Nullable(), ShouldBeTrue)
+		So(Column{Type: "TEXT"}.Nullable(), ShouldBeFalse)
+		So(Column{Type: "NULL"}.Nullable(), ShouldBeTrue)
+		So(Column{Type: "NULL"}.BaseType(), ShouldEqual, "")
+		So(Column{Type: "not null"}.Nullable(), ShouldBeFalse)
+		So(Column{Type: "not null"}.BaseType(), ShouldEqual, "")
+		So(Column{Type: "NONNULL"}.Nullable(), ShouldBeFalse)
+	})
+
+	Convey("Constraints are not part of the type", t, func() {
+		So(Column{Type: "BIGINT UNIQUE"}.BaseType(), ShouldEqual, "BIGINT")
+		So(Column{Type: "bigint primary  key"}.BaseType(), ShouldEqual, "bigint")
+		So(Column{Type: "TEXT UNIQUE NULL"}.BaseType(), ShouldEqual, "TEXT")
+		So(Column{Type: "TEXT UNIQUE NULL"}.Nullable(), ShouldBeTrue)
+		So(Column{Type: "TEXT NULL UNIQUE"}.Nullable(), ShouldBeTrue)
+		So(Column{Type: "unique_code"}.BaseType(), ShouldEqual, "unique_code")
+
+		rest, unique, primaryKey := typeConstraints("BIGINT NOT NULL UNIQUE")
+		So(rest, ShouldEqual, "BIGINT NOT NULL")
+		So(unique, ShouldBeTrue)
+		So(primaryKey, ShouldBeFalse)
+		So(TypeMap{Types: map[string]string{"BIGINT": "int64"}, Default: "string"}.TypeOf(Column{Name: "c", Type: "BIGINT UNIQUE"}), ShouldEqual, "int64")
+	})
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWidth(t *testing.T) {
+	Convey("Wide characters take two cells", t, func() {
+		So(textCells("user"), ShouldEqual, 4)
+		So(textCells("ユーザー"), ShouldEqual, 8)
+		So(textCells("ｱ"), ShouldEqual, 1)
+	})
+
+	Convey("Fullwidth forms are wide but halfwidth ones are not", t, func() {
+		So(isWide('Ａ'), ShouldBeTrue)
+		So(isWide('한'), ShouldBeTrue)
+		So(isWide('A'), ShouldBeFalse)
+		So(isWide('ｱ'), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var yamlPlain = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// yamlKeywords are the plain scalars YAML reads as something else than a
+// string.
+var yamlKeywords = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true,
+	"off": true, "y": true, "n": true, "null": true,
+}
+
+// yamlString returns s as a plain YAML scalar if it reads back as the same
+// string, or as a double-quoted one, whose escapes are those of Go.
+func yamlString(s string) string {
+	if yamlPlain.MatchString(s) && !yamlKeywords[strings.ToLower(s)] {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// yamlNode is a JSON value read with the order of its keys kept.
+type yamlNode struct {
+	// scalar is the YAML of a string, number, boolean or null.
+	scalar string
+	keys   []string
+	values []*yamlNode
+	items  []*yamlNode
+	isList bool
+}
+
+func readYAMLNode(d *json.Decoder) (*yamlNode, error) {
+	token, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := token.(type) {
+	case json.Delim:
+		node := &yamlNode{isList: v == '['}
+		for d.More() {
+			if v == '{' {
+				key, err := d.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key.(string))
+			}
+			value, err := readYAMLNode(d)
+			if err != nil {
+				return nil, err
+			}
+			if v == '{' {
+				node.values = append(node.values, value)
+			} else {
+				node.items = append(node.items, value)
+			}
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yamlNode{scalar: yamlString(v)}, nil
+	case json.Number:
+		return &yamlNode{scalar: v.String()}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(v)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", token)
+}
+
+// inline returns the node as a YAML value on the line of its key, if it
+// fits there.
+func (n *yamlNode) inline() (string, bool) {
+	switch {
+	case n.isList && len(n.items) == 0:
+		return "[]", true
+	case n.isList:
+		return "", false
+	case n.keys == nil && n.scalar == "":
+		return "{}", true
+	case n.keys == nil:
+		return n.scalar, true
+	}
+	return "", false
+}
+
+func (n *yamlNode) write(buf *bytes.Buffer, indent string) {
+	if n.isList {
+		for _, item := range n.items {
+			if value, ok := item.inline(); ok {
+				buf.WriteString(indent + "- " + value + "\n")
+				continue
+			}
+			// the first line of the item follows the dash
+			var inner bytes.Buffer
+			item.write(&inner, indent+"  ")
+			buf.WriteString(indent + "- " + strings.TrimPrefix(inner.String(), indent+"  "))
+		}
+		return
+	}
+	for i, key := range n.keys {
+		value := n.values[i]
+		if v, ok := value.inline(); ok {
+			buf.WriteString(indent + yamlString(key) + ": " + v + "\n")
+			continue
+		}
+		buf.WriteString(indent + yamlString(key) + ":\n")
+		value.write(buf, indent+"  ")
+	}
+}
+
+// yamlFromJSON converts a JSON document to block style YAML, keeping the
+// order of the keys.
+func yamlFromJSON(data []byte) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	node, err := readYAMLNode(d)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := node.inline(); ok {
+		return []byte(value + "\n"), nil
+	}
+	var buf bytes.Buffer
+	node.write(&buf, "")
+	return buf.Bytes(), nil
+}